@@ -72,5 +72,8 @@ func init() {
 	rootCmd.AddCommand(setupInstallCommand())
 	rootCmd.AddCommand(setupUninstallCommand())
 	rootCmd.AddCommand(setupInfoCommand())
+	rootCmd.AddCommand(setupSecretsBaselineCommand())
+	rootCmd.AddCommand(setupPairCommand())
+	rootCmd.AddCommand(setupCommitCommand())
 	rootCmd.AddCommand(hookCommand)
 }
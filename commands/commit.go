@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/captainhook-go/captainhook/exec"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/io"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func setupCommitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit",
+		Short: "Composes a commit message and commits",
+		Long:  "Asks for all parts of a commit message, validates them against your commit-msg actions and commits the staged changes",
+		Run: func(cmd *cobra.Command, args []string) {
+			conf, err := setUpConfig(cmd, true)
+			if err != nil {
+				DisplayCommandError(err)
+			}
+
+			repo, errRepo := git.NewRepository(conf.GitDirectory())
+			if errRepo != nil {
+				DisplayCommandError(errRepo)
+			}
+
+			io.ColorStatus(conf.AnsiColors())
+			appIO := io.NewDefaultIO(conf.Verbosity(), map[string]string{}, map[string]string{})
+
+			composer := exec.NewCommitComposer(appIO, conf, repo)
+			composerError := composer.Run()
+			_ = repo.Close()
+
+			if composerError != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	configurationAware(cmd)
+	repositoryAware(cmd)
+
+	return cmd
+}
@@ -87,9 +87,13 @@ func setupHookSubCommand(hook string) *cobra.Command {
 
 			io.ColorStatus(conf.AnsiColors())
 			appIO := io.NewDefaultIO(conf.Verbosity(), opts, mapArgs(info.HookArguments(hook), args, hook))
+			if interactionFlag {
+				appIO.Input().DisableInteraction()
+			}
 			runner := exec.NewHookRunner(hook, appIO, conf, repo)
 
 			errRun := runner.Run()
+			_ = repo.Close()
 			if errRun != nil {
 				os.Exit(1)
 			}
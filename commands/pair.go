@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"github.com/captainhook-go/captainhook/exec"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks/actions/message"
+	"github.com/captainhook-go/captainhook/io"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func setupPairCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pair [alias...]",
+		Short: "Starts or ends a pairing session",
+		Long:  "Adds a Co-authored-by trailer for every co-author to your commit messages using the Message.InjectCoAuthors action",
+		Run: func(cmd *cobra.Command, args []string) {
+			clearSession, _ := cmd.Flags().GetBool("clear")
+			duration, _ := cmd.Flags().GetDuration("expire")
+			coAuthorsFile, _ := cmd.Flags().GetString("coauthors-file")
+
+			conf, err := setUpConfig(cmd, true)
+			if err != nil {
+				DisplayCommandError(err)
+			}
+
+			repo, errRepo := git.NewRepository(conf.GitDirectory())
+			if errRepo != nil {
+				DisplayCommandError(errRepo)
+			}
+
+			io.ColorStatus(conf.AnsiColors())
+			appIO := io.NewDefaultIO(conf.Verbosity(), map[string]string{}, map[string]string{})
+
+			pair := exec.NewPair(appIO, repo)
+			pair.Aliases(args)
+			pair.Clear(clearSession)
+			pair.Duration(duration)
+			pair.CoAuthorsFile(coAuthorsFile)
+			if pairError := pair.Run(); pairError != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	setUpPairFlags(cmd)
+	configurationAware(cmd)
+	repositoryAware(cmd)
+
+	return cmd
+}
+
+func setUpPairFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("clear", "", false, "end the current pairing session")
+	cmd.Flags().DurationP("expire", "e", message.DefaultPairDuration, "duration until the session ends")
+	cmd.Flags().StringP("coauthors-file", "", message.DefaultCoAuthorsFile, "team file to resolve the aliases")
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/captainhook-go/captainhook/exec"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/io"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func setupSecretsBaselineCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets-baseline",
+		Short: "Creates or updates the baseline of accepted secrets",
+		Long:  "Scans all files in the index and accepts every secret found by the File.BlockSecrets action",
+		Run: func(cmd *cobra.Command, args []string) {
+			update, _ := cmd.Flags().GetBool("update")
+
+			conf, err := setUpConfig(cmd, true)
+			if err != nil {
+				DisplayCommandError(err)
+			}
+
+			repo, errRepo := git.NewRepository(conf.GitDirectory())
+			if errRepo != nil {
+				DisplayCommandError(errRepo)
+			}
+
+			io.ColorStatus(conf.AnsiColors())
+			appIO := io.NewDefaultIO(conf.Verbosity(), map[string]string{}, map[string]string{})
+
+			baseline := exec.NewSecretsBaseline(appIO, conf, repo)
+			baseline.Update(update)
+			baselineError := baseline.Run()
+			_ = repo.Close()
+
+			if baselineError != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	setUpSecretsBaselineFlags(cmd)
+	configurationAware(cmd)
+	repositoryAware(cmd)
+
+	return cmd
+}
+
+func setUpSecretsBaselineFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("update", "u", false, "keep existing entries and only add new findings")
+}
@@ -53,6 +53,30 @@ func (o Options) AsInt(option string, defaultValue int) int {
 	return defaultValue
 }
 
+// AsFloat tries to convert an option value to a float and return it
+func (o Options) AsFloat(option string, defaultValue float64) float64 {
+	var value, ok = o.options[option]
+	if ok {
+		switch value := value.(type) {
+		case int:
+			return float64(value)
+		case int64:
+			return float64(value)
+		case float64:
+			return value
+		case string:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return defaultValue
+			}
+			return f
+		default:
+			return defaultValue
+		}
+	}
+	return defaultValue
+}
+
 // AsString tries to convert an option value to a string and return it
 func (o Options) AsString(option string, defaultValue string) string {
 	var value, ok = o.options[option]
@@ -36,3 +36,17 @@ func TestOptionsDefaultValue(t *testing.T) {
 		t.Errorf("Could not find option, got: %s, want: %s.", result, expected)
 	}
 }
+
+func TestOptionsFloatValue(t *testing.T) {
+	opt := Options{options: map[string]interface{}{"foo": 3.5, "bar": 10, "baz": "1.5"}}
+
+	if result := opt.AsFloat("foo", 0); result != 3.5 {
+		t.Errorf("Could not find option, got: %f, want: %f.", result, 3.5)
+	}
+	if result := opt.AsFloat("bar", 0); result != 10 {
+		t.Errorf("Could not convert int option, got: %f, want: %f.", result, 10.0)
+	}
+	if result := opt.AsFloat("baz", 0); result != 1.5 {
+		t.Errorf("Could not convert string option, got: %f, want: %f.", result, 1.5)
+	}
+}
@@ -0,0 +1,282 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/events"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks/actions/message"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+const (
+	composeType = iota
+	composeScope
+	composeSubject
+	composeBody
+	composeIssue
+	composeBreaking
+)
+
+// CommitComposer asks for all parts of a commit message and commits with the composed message
+// Every answer is validated against the `commit-msg` actions configured in the configuration,
+// so you know about failing rules before git executes the `commit-msg` hook.
+type CommitComposer struct {
+	appIO   io.IO
+	config  *configuration.Configuration
+	repo    git.Repo
+	answers map[int]string
+}
+
+// Run executes the CommitComposer
+func (c *CommitComposer) Run() error {
+	// without a terminal no answers can be given and required questions would be asked forever
+	if !c.appIO.IsInteractive() {
+		err := errors.New("composing a commit message requires an interactive terminal")
+		c.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+		return err
+	}
+	msg := c.compose()
+	for {
+		problems, err := c.validate(msg)
+		if err != nil {
+			c.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+			return err
+		}
+		if len(problems) == 0 {
+			break
+		}
+		c.writeProblems(problems)
+		if !c.confirm("Do you want to edit your message? [y/N] ", "n") {
+			c.appIO.Write("<warning>commit aborted</warning>", true, io.NORMAL)
+			return errors.New("commit message did not follow all rules")
+		}
+		msg = c.compose()
+	}
+	return c.commit(msg)
+}
+
+// compose asks all questions, previous answers are used as default values
+// Answers are validated right away, if a new problem occurs you can change your answer.
+func (c *CommitComposer) compose() string {
+	var known []string
+	for step := composeType; step <= composeBreaking; step++ {
+		c.ask(step)
+		if step < composeSubject {
+			continue
+		}
+		problems, err := c.validate(c.message())
+		if err != nil {
+			continue
+		}
+		var added []string
+		for _, problem := range problems {
+			if !slices.Contains(known, problem) {
+				added = append(added, problem)
+			}
+		}
+		if len(added) > 0 {
+			c.writeProblems(added)
+			if c.confirm("Do you want to change your answer? [y/N] ", "n") {
+				// type and scope problems can only be detected once the subject is known
+				if step == composeSubject {
+					step = composeType - 1
+				} else {
+					step--
+				}
+				continue
+			}
+		}
+		known = problems
+	}
+	return c.message()
+}
+
+func (c *CommitComposer) ask(step int) {
+	switch step {
+	case composeType:
+		c.answers[step] = c.question(
+			"Type of change ("+strings.Join(message.DefaultConventionalTypes, ", ")+")",
+			c.answers[step],
+		)
+	case composeScope:
+		c.answers[step] = c.question("Scope of the change (optional)", c.answers[step])
+	case composeSubject:
+		for {
+			c.answers[step] = c.question("Short description", c.answers[step])
+			if c.answers[step] != "" {
+				break
+			}
+			c.appIO.Write("<warning>the description is required</warning>", true, io.NORMAL)
+		}
+	case composeBody:
+		c.appIO.Write("Longer description, finish with an empty line", true, io.NORMAL)
+		var lines []string
+		for {
+			line := c.appIO.Ask("  ", "")
+			if line == "" {
+				break
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 || c.answers[step] == "" {
+			c.answers[step] = strings.Join(lines, "\n")
+		}
+	case composeIssue:
+		c.answers[step] = c.question("Issue key (optional)", c.answers[step])
+	case composeBreaking:
+		c.answers[step] = c.question("Breaking change note (optional)", c.answers[step])
+	}
+}
+
+// question asks for a value and displays the default value
+func (c *CommitComposer) question(question, defaultValue string) string {
+	hint := ""
+	if defaultValue != "" {
+		hint = " [<comment>" + defaultValue + "</comment>]"
+	}
+	return strings.TrimSpace(c.appIO.Ask(question+hint+": ", defaultValue))
+}
+
+func (c *CommitComposer) confirm(question, defaultValue string) bool {
+	return strings.ToLower(c.appIO.Ask(question, defaultValue)) == "y"
+}
+
+// message composes the commit message from all answers
+//
+//	type(scope)!: subject
+//
+//	body
+//
+//	Refs: issue
+//	BREAKING CHANGE: note
+func (c *CommitComposer) message() string {
+	subject := c.answers[composeSubject]
+	if c.answers[composeType] != "" {
+		header := c.answers[composeType]
+		if c.answers[composeScope] != "" {
+			header += "(" + c.answers[composeScope] + ")"
+		}
+		if c.answers[composeBreaking] != "" {
+			header += "!"
+		}
+		subject = header + ": " + subject
+	}
+
+	parts := []string{subject}
+	if c.answers[composeBody] != "" {
+		parts = append(parts, c.answers[composeBody])
+	}
+	var footers []string
+	if c.answers[composeIssue] != "" {
+		footers = append(footers, "Refs: "+c.answers[composeIssue])
+	}
+	if c.answers[composeBreaking] != "" {
+		footers = append(footers, "BREAKING CHANGE: "+c.answers[composeBreaking])
+	}
+	if len(footers) > 0 {
+		parts = append(parts, strings.Join(footers, "\n"))
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// validate runs all internal `commit-msg` actions against a message and returns the problems
+func (c *CommitComposer) validate(msg string) ([]string, error) {
+	msgFile, err := c.writeMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(msgFile) }()
+
+	vIO := io.NewDefaultIO(
+		c.appIO.Verbosity(),
+		map[string]string{},
+		map[string]string{info.ArgCommitMsgFile: msgFile, info.ArgCommand: info.CommitMsg},
+	)
+	runner := NewActionRunner(vIO, c.config, c.repo, events.NewDispatcher())
+
+	var problems []string
+	for _, action := range c.config.HookConfig(info.CommitMsg).GetActions() {
+		if !isInternalFunctionality(action.Run()) {
+			continue
+		}
+		result := runner.Run(info.CommitMsg, action)
+		if result.Status != info.ActionFailed {
+			continue
+		}
+		problems = append(problems, c.actionProblems(result)...)
+	}
+	return problems, nil
+}
+
+// actionProblems extracts the problems from the output of a failed action
+// The output of the commit message box is skipped, if there is no further output the error is used.
+func (c *CommitComposer) actionProblems(result *ActionResult) []string {
+	errText := ""
+	if result.RunErr != nil {
+		errText = result.RunErr.Error()
+	}
+	var problems []string
+	inBox := false
+	for _, m := range result.Log.Messages() {
+		line := strings.TrimSpace(m.Message)
+		if strings.HasPrefix(line, "===") {
+			inBox = !inBox
+			continue
+		}
+		if inBox || m.Verbosity > io.NORMAL || line == "" || line == errText {
+			continue
+		}
+		problems = append(problems, line)
+	}
+	if len(problems) == 0 && errText != "" {
+		problems = append(problems, errText)
+	}
+	return problems
+}
+
+func (c *CommitComposer) writeProblems(problems []string) {
+	for _, problem := range problems {
+		c.appIO.Write("  <warning>"+problem+"</warning>", true, io.NORMAL)
+	}
+}
+
+// writeMessage writes the message to a temporary file and returns the path
+func (c *CommitComposer) writeMessage(msg string) (string, error) {
+	file, err := os.CreateTemp("", "captainhook-commit-msg-")
+	if err != nil {
+		return "", fmt.Errorf("could not create commit message file: %s", err.Error())
+	}
+	defer func() { _ = file.Close() }()
+	if _, err = file.WriteString(msg); err != nil {
+		return "", fmt.Errorf("could not write commit message file: %s", err.Error())
+	}
+	return file.Name(), nil
+}
+
+// commit executes `git commit -F` with the composed message
+// The git output and the output of all executed hooks is passed through.
+func (c *CommitComposer) commit(msg string) error {
+	msgFile, err := c.writeMessage(msg)
+	if err != nil {
+		c.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+		return err
+	}
+	defer func() { _ = os.Remove(msgFile) }()
+
+	cmd := exec.Command("git", "commit", "-F", msgFile)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func NewCommitComposer(appIO io.IO, config *configuration.Configuration, repo git.Repo) *CommitComposer {
+	return &CommitComposer{appIO: appIO, config: config, repo: repo, answers: map[int]string{}}
+}
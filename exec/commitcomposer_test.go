@@ -0,0 +1,40 @@
+package exec
+
+import (
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestCommitComposerMessage(t *testing.T) {
+	c := NewCommitComposer(test.CreateFakeIO(), test.CreateFakeConfig(), test.CreateFakeRepo())
+	c.answers[composeSubject] = "add token endpoint"
+	if msg := c.message(); msg != "add token endpoint\n" {
+		t.Errorf("Wrong message, got %q", msg)
+	}
+
+	c.answers[composeType] = "feat"
+	c.answers[composeScope] = "api"
+	c.answers[composeBody] = "Some body"
+	c.answers[composeIssue] = "ABC-1"
+	c.answers[composeBreaking] = "tokens expire"
+	expected := "feat(api)!: add token endpoint\n\nSome body\n\nRefs: ABC-1\nBREAKING CHANGE: tokens expire\n"
+	if msg := c.message(); msg != expected {
+		t.Errorf("Wrong message, expected %q got %q", expected, msg)
+	}
+}
+
+func TestCommitComposerFailsWithoutTerminal(t *testing.T) {
+	appIO := test.CreateFakeIO()
+	appIO.SetInteractive(false)
+	c := NewCommitComposer(appIO, test.CreateFakeConfig(), test.CreateFakeRepo())
+
+	if err := c.Run(); err == nil {
+		t.Errorf("Composing without a terminal should fail")
+	}
+	for _, out := range appIO.Out {
+		if strings.Contains(out, "Short description") {
+			t.Errorf("No questions should be asked without a terminal")
+		}
+	}
+}
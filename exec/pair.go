@@ -0,0 +1,113 @@
+package exec
+
+import (
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks/actions/message"
+	"github.com/captainhook-go/captainhook/io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Pair manages the co-authors of a pairing or mob session
+// The co-authors get added to every commit message by the `Message.InjectCoAuthors` action.
+// Aliases are resolved with the team file '.git-coauthors' in the repository root.
+type Pair struct {
+	appIO         io.IO
+	repo          git.Repo
+	aliases       []string
+	clear         bool
+	duration      time.Duration
+	coAuthorsFile string
+}
+
+// Aliases sets the co-authors to pair with
+func (p *Pair) Aliases(aliases []string) {
+	p.aliases = aliases
+}
+
+// Clear ends the current session
+func (p *Pair) Clear(clear bool) {
+	p.clear = clear
+}
+
+// Duration sets how long the session is active
+func (p *Pair) Duration(duration time.Duration) {
+	p.duration = duration
+}
+
+// CoAuthorsFile sets the team file used to resolve the aliases
+func (p *Pair) CoAuthorsFile(path string) {
+	p.coAuthorsFile = path
+}
+
+// Run executes the Pair command
+// Without aliases the current session is displayed.
+func (p *Pair) Run() error {
+	if p.clear {
+		if err := message.ClearPairSession(p.repo.GitDir()); err != nil {
+			p.appIO.Write("<warning>could not clear pairing session</warning>", true, io.NORMAL)
+			return err
+		}
+		p.appIO.Write("<ok>pairing session cleared</ok>", true, io.NORMAL)
+		return nil
+	}
+	if len(p.aliases) == 0 {
+		return p.show()
+	}
+
+	path := p.coAuthorsFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.repo.Path(), path)
+	}
+	coAuthors, err := message.LoadCoAuthors(path)
+	if err != nil {
+		p.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+		return err
+	}
+	var resolved []string
+	for _, alias := range p.aliases {
+		author, resolveErr := coAuthors.Resolve(alias)
+		if resolveErr != nil {
+			p.appIO.Write("<warning>"+resolveErr.Error()+"</warning>", true, io.NORMAL)
+			return resolveErr
+		}
+		resolved = append(resolved, author)
+	}
+
+	session := message.NewPairSession(resolved, p.duration)
+	if err = session.Save(p.repo.GitDir()); err != nil {
+		p.appIO.Write("<warning>could not save pairing session</warning>", true, io.NORMAL)
+		return err
+	}
+	p.appIO.Write("<ok>pairing with</ok> "+strings.Join(resolved, ", "), true, io.NORMAL)
+	p.appIO.Write("session expires at "+session.Expires.Format(time.DateTime), true, io.VERBOSE)
+	return nil
+}
+
+func (p *Pair) show() error {
+	session, err := message.LoadPairSession(p.repo.GitDir())
+	if err != nil {
+		p.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+		return err
+	}
+	if !session.IsActive() {
+		p.appIO.Write("no active pairing session", true, io.NORMAL)
+		return nil
+	}
+	p.appIO.Write("pairing with:", true, io.NORMAL)
+	for _, coAuthor := range session.CoAuthors {
+		p.appIO.Write("  - "+coAuthor, true, io.NORMAL)
+	}
+	p.appIO.Write("session expires at <comment>"+session.Expires.Format(time.DateTime)+"</comment>", true, io.NORMAL)
+	return nil
+}
+
+func NewPair(appIO io.IO, repo git.Repo) *Pair {
+	return &Pair{
+		appIO:         appIO,
+		repo:          repo,
+		duration:      message.DefaultPairDuration,
+		coAuthorsFile: message.DefaultCoAuthorsFile,
+	}
+}
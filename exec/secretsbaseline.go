@@ -0,0 +1,102 @@
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks/actions/file"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+)
+
+// SecretsBaseline creates the baseline file of accepted secrets used by the `File.BlockSecrets` action
+// It scans all files in the index with the options of the first configured `File.BlockSecrets` action.
+// By default, the baseline gets overwritten, in `update` mode new findings are added to the existing baseline.
+type SecretsBaseline struct {
+	appIO  io.IO
+	config *configuration.Configuration
+	repo   git.Repo
+	update bool
+}
+
+// Update makes sure existing baseline entries are kept
+func (s *SecretsBaseline) Update(update bool) {
+	s.update = update
+}
+
+// Run executes the SecretsBaseline
+func (s *SecretsBaseline) Run() error {
+	action := s.findBlockSecretsAction()
+	if action == nil {
+		err := errors.New("no 'File.BlockSecrets' action configured")
+		s.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+		return err
+	}
+
+	scanner, err := file.NewSecretScanner(action.Options(), s.repo.Path())
+	if err != nil {
+		s.appIO.Write("<warning>"+err.Error()+"</warning>", true, io.NORMAL)
+		return err
+	}
+
+	path := file.SecretsBaselinePath(action.Options(), s.repo.Path())
+	baseline := file.NewSecretsBaseline()
+	if s.update {
+		baseline, err = file.LoadSecretsBaseline(path)
+		if err != nil {
+			s.appIO.Write("<warning>could not read baseline</warning>", true, io.NORMAL)
+			return err
+		}
+	}
+	existing := baseline.Size()
+
+	files, err := s.repo.TrackedFiles()
+	if err != nil {
+		s.appIO.Write("<warning>could not list files</warning>", true, io.NORMAL)
+		return err
+	}
+	for _, f := range files {
+		content, readErr := s.repo.FileContent(f, "")
+		if readErr != nil || bytes.IndexByte(content, 0) > -1 {
+			// skip unreadable and binary files
+			continue
+		}
+		for _, finding := range scanner.ScanContent(f, string(content)) {
+			s.appIO.Write("  - "+finding.String(), true, io.VERBOSE)
+			baseline.Add(finding)
+		}
+	}
+
+	if err = baseline.Save(path); err != nil {
+		s.appIO.Write("<warning>could not write baseline</warning>", true, io.NORMAL)
+		return err
+	}
+	s.appIO.Write(
+		fmt.Sprintf("<ok>baseline written to '%s'</ok> (%d new, %d total)", path, baseline.Size()-existing, baseline.Size()),
+		true,
+		io.NORMAL,
+	)
+	return nil
+}
+
+// findBlockSecretsAction returns the first `File.BlockSecrets` action configured for any hook
+func (s *SecretsBaseline) findBlockSecretsAction() *configuration.Action {
+	for _, hook := range info.GetValidHooks() {
+		for _, action := range s.config.HookConfig(hook).GetActions() {
+			if !isInternalFunctionality(action.Run()) {
+				continue
+			}
+			path := splitInternalPath(action.Run())
+			if len(path) == 2 && path[0] == "file" && path[1] == "blocksecrets" {
+				return action
+			}
+		}
+	}
+	return nil
+}
+
+func NewSecretsBaseline(appIO io.IO, config *configuration.Configuration, repo git.Repo) *SecretsBaseline {
+	return &SecretsBaseline{appIO: appIO, config: config, repo: repo}
+}
@@ -0,0 +1,13 @@
+package add
+
+import "github.com/captainhook-go/captainhook/git/types"
+
+// Files sets the list of files to add to the index
+func Files(files []string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption("--")
+		for _, file := range files {
+			g.AddOption(file)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package add
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	g := types.NewCmd("add")
+	g.AddOptions(Files([]string{"foo.go", "-bar.go"}))
+
+	if len(g.Options) < 4 {
+		t.Errorf("Options not added correctly")
+	}
+	if g.Options[1] != "--" || g.Options[2] != "foo.go" || g.Options[3] != "-bar.go" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
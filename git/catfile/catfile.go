@@ -0,0 +1,11 @@
+package catfile
+
+import "github.com/captainhook-go/captainhook/git/types"
+
+// Type shows the type of an object e.g. 'commit' or 'tag'
+func Type(object string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption("-t")
+		g.AddOption(object)
+	}
+}
@@ -0,0 +1,18 @@
+package catfile
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+func TestType(t *testing.T) {
+	g := types.NewCmd("cat-file")
+	g.AddOptions(Type("abc"))
+
+	if len(g.Options) < 3 {
+		t.Errorf("Options not added correctly")
+	}
+	if g.Options[1] != "-t" || g.Options[2] != "abc" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
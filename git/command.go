@@ -31,11 +31,26 @@ func SetDebug(debug bool) types.Option {
 	}
 }
 
+// Add sets up a `git add` cli command
+func Add(options ...types.Option) (string, error) {
+	return command(context.Background(), "add", options...)
+}
+
+// CatFile sets up a `git cat-file` cli command
+func CatFile(options ...types.Option) (string, error) {
+	return command(context.Background(), "cat-file", options...)
+}
+
 // Config sets up a `git config` cli command
 func Config(options ...types.Option) (string, error) {
 	return command(context.Background(), "config", options...)
 }
 
+// Diff sets up a `git diff` cli command
+func Diff(options ...types.Option) (string, error) {
+	return command(context.Background(), "diff", options...)
+}
+
 // DiffIndex sets up a `git diff-index` cli command
 func DiffIndex(options ...types.Option) (string, error) {
 	return command(context.Background(), "diff-index", options...)
@@ -51,7 +66,27 @@ func Log(options ...types.Option) (string, error) {
 	return command(context.Background(), "log", options...)
 }
 
+// LsFiles sets up a `git ls-files` cli command
+func LsFiles(options ...types.Option) (string, error) {
+	return command(context.Background(), "ls-files", options...)
+}
+
+// MergeBase sets up a `git merge-base` cli command
+func MergeBase(options ...types.Option) (string, error) {
+	return command(context.Background(), "merge-base", options...)
+}
+
+// RevList sets up a `git rev-list` cli command
+func RevList(options ...types.Option) (string, error) {
+	return command(context.Background(), "rev-list", options...)
+}
+
 // RevParse sets up a `git rev-parse` cli command
 func RevParse(options ...types.Option) (string, error) {
 	return command(context.Background(), "rev-parse", options...)
 }
+
+// Tag sets up a `git tag` cli command
+func Tag(options ...types.Option) (string, error) {
+	return command(context.Background(), "tag", options...)
+}
@@ -25,6 +25,21 @@ func FromTo(from, to string) func(*types.Cmd) {
 	}
 }
 
+// DefaultPrefixes makes sure the output uses the 'a/' and 'b/' prefixes no matter how git is configured
+func DefaultPrefixes(g *types.Cmd) {
+	g.AddOption("--src-prefix=a/")
+	g.AddOption("--dst-prefix=b/")
+}
+
+// FindRenames detects renamed files instead of showing them as deleted and added
+func FindRenames(g *types.Cmd) {
+	g.AddOption("-M")
+}
+
+func NoColor(g *types.Cmd) {
+	g.AddOption("--no-color")
+}
+
 func NoExtDiff(g *types.Cmd) {
 	g.AddOption("--no-ext-diff")
 }
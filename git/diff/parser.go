@@ -0,0 +1,173 @@
+package diff
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// ParseUnified converts the output of `git diff` in unified format to a Diff struct
+// The output has to be created with the default 'a/' and 'b/' path prefixes.
+func ParseUnified(out string) *types.Diff {
+	d := &types.Diff{}
+
+	var file *types.FileDiff
+	var hunk *types.Hunk
+	oldLine, newLine := 0, 0
+	oldLeft, newLeft := 0, 0
+
+	// don't use a line scanner here, minified files can easily exceed its line limit
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			file = newFileDiff(line)
+			hunk = nil
+			d.Files = append(d.Files, file)
+			continue
+		}
+		if file == nil {
+			continue
+		}
+		if hunk != nil && strings.HasPrefix(line, "\\") {
+			// "\ No newline at end of file"
+			continue
+		}
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				hunk.Lines = append(hunk.Lines, &types.DiffLine{Type: types.DiffLineAdded, Content: line[1:], NewNumber: newLine})
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				hunk.Lines = append(hunk.Lines, &types.DiffLine{Type: types.DiffLineRemoved, Content: line[1:], OldNumber: oldLine})
+				oldLine++
+				oldLeft--
+			default:
+				// context lines start with a space, empty lines are possible if the output got trimmed
+				hunk.Lines = append(hunk.Lines, &types.DiffLine{Type: types.DiffLineContext, Content: strings.TrimPrefix(line, " "), OldNumber: oldLine, NewNumber: newLine})
+				oldLine++
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+		if match := hunkHeader.FindStringSubmatch(line); match != nil {
+			hunk = &types.Hunk{
+				OldStart: toInt(match[1], 0),
+				OldLines: toInt(match[2], 1),
+				NewStart: toInt(match[3], 0),
+				NewLines: toInt(match[4], 1),
+				Section:  match[5],
+			}
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+			file.Hunks = append(file.Hunks, hunk)
+			continue
+		}
+		parseExtendedHeader(file, line)
+	}
+	return d
+}
+
+// parseExtendedHeader handles all header lines between `diff --git` and the first hunk
+func parseExtendedHeader(file *types.FileDiff, line string) {
+	switch {
+	case strings.HasPrefix(line, "new file mode"):
+		file.Status = types.FileStatusAdded
+		file.OldPath = ""
+	case strings.HasPrefix(line, "deleted file mode"):
+		file.Status = types.FileStatusDeleted
+		file.NewPath = ""
+	case strings.HasPrefix(line, "rename from "):
+		file.Status = types.FileStatusRenamed
+		file.OldPath = unquote(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		file.NewPath = unquote(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "copy from "):
+		file.Status = types.FileStatusCopied
+		file.OldPath = unquote(strings.TrimPrefix(line, "copy from "))
+	case strings.HasPrefix(line, "copy to "):
+		file.NewPath = unquote(strings.TrimPrefix(line, "copy to "))
+	case strings.HasPrefix(line, "Binary files "), strings.HasPrefix(line, "GIT binary patch"):
+		file.IsBinary = true
+	case strings.HasPrefix(line, "--- "):
+		if p := stripPrefix(headerPath(line), "a/"); p != "" {
+			file.OldPath = p
+		}
+	case strings.HasPrefix(line, "+++ "):
+		if p := stripPrefix(headerPath(line), "b/"); p != "" {
+			file.NewPath = p
+		}
+	}
+}
+
+// newFileDiff creates a FileDiff from the `diff --git a/path b/path` line
+// The paths are only a best guess since they are ambiguous if they contain spaces,
+// they get corrected by the `---`, `+++`, `rename` or `copy` header lines.
+func newFileDiff(line string) *types.FileDiff {
+	file := &types.FileDiff{Status: types.FileStatusModified}
+	paths := strings.TrimPrefix(line, "diff --git ")
+
+	if strings.HasPrefix(paths, "\"") {
+		if end := strings.Index(paths[1:], "\" "); end > -1 {
+			file.OldPath = stripPrefix(unquote(paths[:end+2]), "a/")
+			file.NewPath = stripPrefix(unquote(strings.TrimSpace(paths[end+3:])), "b/")
+		}
+		return file
+	}
+	// for unchanged paths the line looks like "a/PATH b/PATH"
+	if len(paths)%2 == 1 {
+		half := (len(paths) - 1) / 2
+		if paths[half] == ' ' && paths[2:half] == paths[half+3:] {
+			file.OldPath = paths[2:half]
+			file.NewPath = paths[half+3:]
+			return file
+		}
+	}
+	parts := strings.SplitN(paths, " b/", 2)
+	file.OldPath = stripPrefix(parts[0], "a/")
+	if len(parts) == 2 {
+		file.NewPath = parts[1]
+	}
+	return file
+}
+
+// headerPath extracts the path from a `---` or `+++` line
+// Git adds a trailing tab if the path contains spaces.
+func headerPath(line string) string {
+	return unquote(strings.TrimSuffix(line[4:], "\t"))
+}
+
+// stripPrefix removes the 'a/' or 'b/' prefix and returns an empty string for /dev/null
+func stripPrefix(path, prefix string) string {
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// unquote handles paths git quoted because of special characters
+func unquote(path string) string {
+	if !strings.HasPrefix(path, "\"") {
+		return path
+	}
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		return path
+	}
+	return unquoted
+}
+
+func toInt(value string, defaultValue int) int {
+	if value == "" {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return i
+}
@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+const testDiff = `diff --git a/plain b/moved
+similarity index 100%
+rename from plain
+rename to moved
+diff --git a/x y.txt b/x y.txt
+index 422c2b7..6372083 100644
+--- a/x y.txt	
++++ b/x y.txt	
+@@ -1,2 +1,3 @@
+ a
+-b
++c
++d
+diff --git "a/\303\274.txt" "b/\303\274.txt"
+new file mode 100644
+index 0000000..3e75765
+--- /dev/null
++++ "b/\303\274.txt"
+@@ -0,0 +1 @@
++new
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 3e75765..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+\ No newline at end of file
+diff --git a/image.png b/image.png
+index 3e75765..4e75765 100644
+Binary files a/image.png and b/image.png differ`
+
+func TestParseUnifiedFiles(t *testing.T) {
+	d := ParseUnified(testDiff)
+
+	if len(d.Files) != 5 {
+		t.Fatalf("Wrong number of files, got: %d, want: %d", len(d.Files), 5)
+	}
+	expected := []struct {
+		status  string
+		oldPath string
+		newPath string
+	}{
+		{types.FileStatusRenamed, "plain", "moved"},
+		{types.FileStatusModified, "x y.txt", "x y.txt"},
+		{types.FileStatusAdded, "", "ü.txt"},
+		{types.FileStatusDeleted, "old.txt", ""},
+		{types.FileStatusModified, "image.png", "image.png"},
+	}
+	for i, e := range expected {
+		f := d.Files[i]
+		if f.Status != e.status || f.OldPath != e.oldPath || f.NewPath != e.newPath {
+			t.Errorf("Wrong file %d, got: %s %s %s, want: %s %s %s", i, f.Status, f.OldPath, f.NewPath, e.status, e.oldPath, e.newPath)
+		}
+	}
+	if !d.Files[4].IsBinary {
+		t.Errorf("File should be detected as binary")
+	}
+}
+
+func TestParseUnifiedLines(t *testing.T) {
+	d := ParseUnified(testDiff)
+
+	file := d.File("x y.txt")
+	if file == nil {
+		t.Fatalf("File 'x y.txt' should be in diff")
+	}
+	added := file.AddedLines()
+	if len(added) != 2 {
+		t.Fatalf("Wrong number of added lines, got: %d, want: %d", len(added), 2)
+	}
+	if added[0].Content != "c" || added[0].NewNumber != 2 {
+		t.Errorf("Wrong added line, got: %d %s, want: 2 c", added[0].NewNumber, added[0].Content)
+	}
+	if added[1].Content != "d" || added[1].NewNumber != 3 {
+		t.Errorf("Wrong added line, got: %d %s, want: 3 d", added[1].NewNumber, added[1].Content)
+	}
+	removed := file.RemovedLines()
+	if len(removed) != 1 || removed[0].Content != "b" || removed[0].OldNumber != 2 {
+		t.Errorf("Wrong removed lines")
+	}
+	deleted := d.File("old.txt")
+	if len(deleted.RemovedLines()) != 1 {
+		t.Errorf("Deleted file should have one removed line")
+	}
+}
@@ -1 +1,20 @@
 package difftree
+
+import "github.com/captainhook-go/captainhook/git/types"
+
+// Commit compares the given commit to its parent
+func Commit(hash string) func(*types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption(hash)
+	}
+}
+
+// Patch outputs the changes as unified diff instead of the raw format
+func Patch(g *types.Cmd) {
+	g.AddOption("-p")
+}
+
+// Root shows root commits as the creation of all their files
+func Root(g *types.Cmd) {
+	g.AddOption("--root")
+}
@@ -1,6 +1,7 @@
 package difftree
 
 import (
+	"github.com/captainhook-go/captainhook/git/types"
 	"testing"
 )
 
@@ -9,3 +10,15 @@ func TestNothing(t *testing.T) {
 		t.Errorf("This is weired")
 	}
 }
+
+func TestRootPatch(t *testing.T) {
+	g := types.NewCmd("diff-tree")
+	g.AddOptions(Patch, Root, Commit("1234567"))
+
+	if len(g.Options) < 4 {
+		t.Errorf("Options not added correctly")
+	}
+	if g.Options[1] != "-p" || g.Options[2] != "--root" || g.Options[3] != "1234567" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
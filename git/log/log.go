@@ -10,6 +10,7 @@ const (
 		"<names><![CDATA[%d]]></names>%n" +
 		"<date>%ci</date>%n" +
 		"<author><![CDATA[%an]]></author>%n" +
+		"<email><![CDATA[%ae]]></email>%n" +
 		"<subject><![CDATA[%s]]></subject>%n" +
 		"<body><![CDATA[%n%b%n]]></body>%n" +
 		"</commit>"
@@ -80,6 +81,15 @@ func NoCommitID(g *types.Cmd) {
 	g.AddOption("--no-commit-id")
 }
 
+// NotOnRemotes limits the log to commits reachable from 'to' but not from any remote ref
+func NotOnRemotes(to string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption(to)
+		g.AddOption("--not")
+		g.AddOption("--remotes")
+	}
+}
+
 // NoMerges is used to exclude merges from the log
 func NoMerges(g *types.Cmd) {
 	g.AddOption("--no-merges")
@@ -16,3 +16,15 @@ func TestAbbrevRev(t *testing.T) {
 		t.Errorf("Wrong option")
 	}
 }
+
+func TestNotOnRemotes(t *testing.T) {
+	g := types.NewCmd("log")
+	g.AddOptions(NotOnRemotes("1234567"))
+
+	if len(g.Options) < 4 {
+		t.Errorf("Option not added correctly")
+	}
+	if g.Options[1] != "1234567" || g.Options[2] != "--not" || g.Options[3] != "--remotes" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
@@ -0,0 +1,20 @@
+package mergebase
+
+import "github.com/captainhook-go/captainhook/git/types"
+
+// IsAncestor checks if the first commit is an ancestor of the second commit
+func IsAncestor(ancestor, descendant string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption("--is-ancestor")
+		g.AddOption(ancestor)
+		g.AddOption(descendant)
+	}
+}
+
+// Commits sets the two commits to find the best common ancestor for
+func Commits(a, b string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption(a)
+		g.AddOption(b)
+	}
+}
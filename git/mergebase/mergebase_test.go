@@ -0,0 +1,18 @@
+package mergebase
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+func TestIsAncestor(t *testing.T) {
+	g := types.NewCmd("merge-base")
+	g.AddOptions(IsAncestor("abc", "def"))
+
+	if len(g.Options) < 4 {
+		t.Errorf("Options not added correctly")
+	}
+	if g.Options[1] != "--is-ancestor" || g.Options[2] != "abc" || g.Options[3] != "def" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
@@ -0,0 +1,150 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// objectReader keeps a single `git cat-file --batch` process running to look up blobs
+// Spawning a git process for every file is slow if a hook has to inspect lots of files,
+// so all lookups are written to the processes stdin and read back from its stdout.
+// The reader is safe to use from concurrently running actions.
+type objectReader struct {
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+// Size returns the size of an object in bytes without loading its content
+func (o *objectReader) Size(object string) (int64, error) {
+	size, _, err := o.read(object, false)
+	return size, err
+}
+
+// Content returns the content of an object
+func (o *objectReader) Content(object string) ([]byte, error) {
+	_, content, err := o.read(object, true)
+	return content, err
+}
+
+// Close stops the cat-file process if it was started
+func (o *objectReader) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cmd == nil {
+		return nil
+	}
+	_ = o.stdin.Close()
+	err := o.cmd.Wait()
+	o.cmd = nil
+	return err
+}
+
+// read requests an object and parses the batch output
+// The batch output looks like this:
+//
+//	<sha> <type> <size>
+//	<content>
+//
+// For objects that can not be found git responds with `<object> missing`.
+func (o *objectReader) read(object string, withContent bool) (int64, []byte, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if strings.ContainsAny(object, "\n\r") {
+		return 0, nil, fmt.Errorf("invalid object name: %s", object)
+	}
+	if err := o.start(); err != nil {
+		return 0, nil, err
+	}
+	if _, err := fmt.Fprintln(o.stdin, object); err != nil {
+		o.reset()
+		return 0, nil, err
+	}
+	header, err := o.stdout.ReadString('\n')
+	if err != nil {
+		o.reset()
+		return 0, nil, err
+	}
+	header = strings.TrimSuffix(header, "\n")
+	if strings.HasSuffix(header, " missing") || strings.HasSuffix(header, " ambiguous") {
+		return 0, nil, fmt.Errorf("object not found: %s", object)
+	}
+	parts := strings.Fields(header)
+	if len(parts) != 3 {
+		o.reset()
+		return 0, nil, fmt.Errorf("unexpected cat-file output: %s", header)
+	}
+	size, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		o.reset()
+		return 0, nil, fmt.Errorf("unexpected cat-file output: %s", header)
+	}
+
+	var content []byte
+	if withContent && parts[1] == "blob" {
+		content = make([]byte, size)
+		_, err = io.ReadFull(o.stdout, content)
+	} else {
+		_, err = io.CopyN(io.Discard, o.stdout, size)
+	}
+	if err != nil {
+		o.reset()
+		return 0, nil, err
+	}
+	// every object is followed by a line feed
+	if _, err = o.stdout.ReadByte(); err != nil {
+		o.reset()
+		return 0, nil, err
+	}
+	if parts[1] != "blob" {
+		return 0, nil, fmt.Errorf("object is a %s not a file: %s", parts[1], object)
+	}
+	return size, content, nil
+}
+
+// start launches the cat-file process on first use
+func (o *objectReader) start() error {
+	if o.cmd != nil {
+		return nil
+	}
+	cmd := exec.Command("git", "cat-file", "--batch")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return errors.New("could not start git cat-file: " + err.Error())
+	}
+	o.cmd = cmd
+	o.stdin = stdin
+	o.stdout = bufio.NewReader(stdout)
+	return nil
+}
+
+// reset kills a process whose output can not be trusted anymore
+// The next lookup will start a fresh process.
+func (o *objectReader) reset() {
+	_ = o.stdin.Close()
+	_ = o.cmd.Process.Kill()
+	_ = o.cmd.Wait()
+	o.cmd = nil
+}
+
+// objectName returns the cat-file object name for a path at a given revision
+// If no revision is given the object name is pointing to the version in the index.
+func objectName(path, revision string) string {
+	return revision + ":" + strings.TrimPrefix(path, "./")
+}
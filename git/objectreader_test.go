@@ -0,0 +1,27 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestObjectNameForIndex(t *testing.T) {
+	name := objectName("./foo/bar.txt", "")
+	if name != ":foo/bar.txt" {
+		t.Errorf("Wrong object name, got: %s, want: %s", name, ":foo/bar.txt")
+	}
+}
+
+func TestObjectNameForRevision(t *testing.T) {
+	name := objectName("foo/bar.txt", "HEAD")
+	if name != "HEAD:foo/bar.txt" {
+		t.Errorf("Wrong object name, got: %s, want: %s", name, "HEAD:foo/bar.txt")
+	}
+}
+
+func TestObjectReaderRejectsLineBreaks(t *testing.T) {
+	reader := &objectReader{}
+	_, err := reader.Content(":foo\nbar")
+	if err == nil {
+		t.Errorf("Object names with line breaks should be rejected")
+	}
+}
@@ -35,6 +35,12 @@ type Repo interface {
 	// StagedFiles returns a list of staged files
 	StagedFiles() ([]string, error)
 
+	// AddFiles adds the given files to the index
+	AddFiles(files []string) error
+
+	// TrackedFiles returns a list of all files in the index
+	TrackedFiles() ([]string, error)
+
 	// ChangedFiles returns a list of changed files
 	ChangedFiles(from, to string) ([]string, error)
 
@@ -43,4 +49,46 @@ type Repo interface {
 
 	// CommitsBetween returns a list of Commit between two hashes
 	CommitsBetween(from string, to string) []*types.Commit
+
+	// CommitsNotOnRemotes returns a list of Commit reachable from 'to' but not from any remote ref
+	CommitsNotOnRemotes(to string) []*types.Commit
+
+	// IsAncestor tells you if the first commit is an ancestor of the second one
+	IsAncestor(ancestor, descendant string) bool
+
+	// MergeBase returns the best common ancestor of two commits
+	MergeBase(a, b string) (string, error)
+
+	// AheadBehind returns the number of commits 'local' is ahead and behind of 'base'
+	AheadBehind(local, base string) (int, int, error)
+
+	// MergeCommitsBetween returns the hashes of all merge commits between two revisions
+	MergeCommitsBetween(from, to string) ([]string, error)
+
+	// Upstream returns the upstream branch of a given branch e.g. 'origin/main'
+	// If no upstream branch is configured an empty string is returned.
+	Upstream(branch string) string
+
+	// ObjectType returns the type of object e.g. 'commit' for lightweight and 'tag' for annotated tags
+	ObjectType(object string) (string, error)
+
+	// Tags returns a list of all local tags
+	Tags() ([]string, error)
+
+	// StagedDiff returns the parsed diff of all staged changes
+	StagedDiff() (*types.Diff, error)
+
+	// DiffBetween returns the parsed diff between two revisions
+	DiffBetween(from, to string) (*types.Diff, error)
+
+	// CommitDiff returns the parsed diff of a single commit, root commits included
+	CommitDiff(hash string) (*types.Diff, error)
+
+	// FileContent returns the content of a file at a given revision
+	// If the revision is empty the content is read from the index.
+	FileContent(path, revision string) ([]byte, error)
+
+	// FileSize returns the size of a file at a given revision in bytes
+	// If the revision is empty the size is read from the index.
+	FileSize(path, revision string) (int64, error)
 }
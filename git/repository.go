@@ -3,16 +3,24 @@ package git
 import (
 	"errors"
 	"fmt"
+	"github.com/captainhook-go/captainhook/git/add"
+	"github.com/captainhook-go/captainhook/git/catfile"
 	"github.com/captainhook-go/captainhook/git/config"
 	"github.com/captainhook-go/captainhook/git/diff"
+	"github.com/captainhook-go/captainhook/git/difftree"
 	"github.com/captainhook-go/captainhook/git/log"
+	"github.com/captainhook-go/captainhook/git/mergebase"
+	"github.com/captainhook-go/captainhook/git/revlist"
 	"github.com/captainhook-go/captainhook/git/revparse"
+	"github.com/captainhook-go/captainhook/git/tag"
 	"github.com/captainhook-go/captainhook/git/types"
 	"github.com/captainhook-go/captainhook/io"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -23,6 +31,7 @@ type Repository struct {
 	root     string
 	gitDir   string
 	hooksDir string
+	objects  *objectReader
 }
 
 func (r *Repository) Path() string {
@@ -116,6 +125,24 @@ func (r *Repository) StagedFiles() ([]string, error) {
 	return io.SplitLines(out), nil
 }
 
+func (r *Repository) AddFiles(files []string) error {
+	// git add -- FILES
+	out, err := Add(add.Files(files))
+	if err != nil {
+		return fmt.Errorf("could not add files: %s", out)
+	}
+	return nil
+}
+
+func (r *Repository) TrackedFiles() ([]string, error) {
+	// git ls-files
+	out, err := LsFiles()
+	if err != nil {
+		return nil, err
+	}
+	return io.SplitLines(out), nil
+}
+
 func (r *Repository) ChangedFiles(from, to string) ([]string, error) {
 	// git diff-tree --diff-algorithm=myers --no-ext-diff --no-commit-id --name-only -r --diff-filter=ADM FROM TO
 	out, err := DiffTree(
@@ -156,6 +183,147 @@ func (r *Repository) CommitsBetween(from string, to string) []*types.Commit {
 	return commits
 }
 
+func (r *Repository) CommitsNotOnRemotes(to string) []*types.Commit {
+	// git log --abbrev-commit --no-merges TO --not --remotes
+	out, err := Log(
+		log.Format(log.XmlFormat),
+		log.AbbrevCommit,
+		log.NoMerges,
+		log.NotOnRemotes(to),
+	)
+	if err != nil {
+		return []*types.Commit{}
+	}
+
+	commits, _ := log.ParseXML("<log>" + out + "</log>")
+	return commits
+}
+
+func (r *Repository) IsAncestor(ancestor, descendant string) bool {
+	// git merge-base --is-ancestor ANCESTOR DESCENDANT
+	_, err := MergeBase(mergebase.IsAncestor(ancestor, descendant))
+	return err == nil
+}
+
+func (r *Repository) MergeBase(a, b string) (string, error) {
+	// git merge-base A B
+	return MergeBase(mergebase.Commits(a, b))
+}
+
+func (r *Repository) AheadBehind(local, base string) (int, int, error) {
+	// git rev-list --left-right --count LOCAL...BASE
+	out, err := RevList(revlist.LeftRight, revlist.Count, revlist.SymmetricDifference(local, base))
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not compare '%s' and '%s': %s", local, base, out)
+	}
+	counts := strings.Fields(out)
+	if len(counts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %s", out)
+	}
+	ahead, _ := strconv.Atoi(counts[0])
+	behind, _ := strconv.Atoi(counts[1])
+	return ahead, behind, nil
+}
+
+func (r *Repository) MergeCommitsBetween(from, to string) ([]string, error) {
+	// git rev-list --merges FROM..TO
+	out, err := RevList(revlist.Merges, revlist.FromTo(from, to))
+	if err != nil {
+		return nil, fmt.Errorf("could not list merge commits: %s", out)
+	}
+	return io.SplitLines(out), nil
+}
+
+func (r *Repository) Upstream(branch string) string {
+	// git rev-parse --abbrev-ref BRANCH@{upstream}
+	out, err := RevParse(revparse.AbbrevRef, revparse.UpstreamOf(branch))
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+func (r *Repository) ObjectType(object string) (string, error) {
+	// git cat-file -t OBJECT
+	out, err := CatFile(catfile.Type(object))
+	if err != nil {
+		return "", fmt.Errorf("could not detect type of '%s': %s", object, out)
+	}
+	return out, nil
+}
+
+func (r *Repository) Tags() ([]string, error) {
+	// git tag --list
+	out, err := Tag(tag.List)
+	if err != nil {
+		return nil, fmt.Errorf("could not list tags: %s", out)
+	}
+	return io.SplitLines(out), nil
+}
+
+func (r *Repository) StagedDiff() (*types.Diff, error) {
+	// git diff --no-ext-diff --no-color -M --src-prefix=a/ --dst-prefix=b/ --cached
+	out, err := Diff(
+		diff.NoExtDiff,
+		diff.NoColor,
+		diff.FindRenames,
+		diff.DefaultPrefixes,
+		diff.Cached,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return diff.ParseUnified(out), nil
+}
+
+func (r *Repository) DiffBetween(from, to string) (*types.Diff, error) {
+	// git diff --no-ext-diff --no-color -M --src-prefix=a/ --dst-prefix=b/ FROM TO
+	out, err := Diff(
+		diff.NoExtDiff,
+		diff.NoColor,
+		diff.FindRenames,
+		diff.DefaultPrefixes,
+		diff.FromTo(from, to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return diff.ParseUnified(out), nil
+}
+
+func (r *Repository) CommitDiff(hash string) (*types.Diff, error) {
+	// git diff-tree --no-ext-diff --no-color -M --src-prefix=a/ --dst-prefix=b/ --no-commit-id -p --root HASH
+	out, err := DiffTree(
+		diff.NoExtDiff,
+		diff.NoColor,
+		diff.FindRenames,
+		diff.DefaultPrefixes,
+		log.NoCommitID,
+		difftree.Patch,
+		difftree.Root,
+		difftree.Commit(hash),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return diff.ParseUnified(out), nil
+}
+
+func (r *Repository) FileContent(path, revision string) ([]byte, error) {
+	// git cat-file --batch <<< REVISION:PATH
+	return r.objects.Content(objectName(path, revision))
+}
+
+func (r *Repository) FileSize(path, revision string) (int64, error) {
+	// git cat-file --batch <<< REVISION:PATH
+	return r.objects.Size(objectName(path, revision))
+}
+
+// Close stops all long-running git processes used by the repository
+func (r *Repository) Close() error {
+	return r.objects.Close()
+}
+
 func NewRepository(gitDir string) (*Repository, error) {
 	repoPath := path.Dir(gitDir)
 	if !isPathARepository(gitDir) {
@@ -175,7 +343,7 @@ func NewRepository(gitDir string) (*Repository, error) {
 			dotGitDir = fmt.Sprintf("%s/%s", repoPath, match[1])
 		}
 	}
-	r := Repository{root: repoPath, gitDir: dotGitDir, hooksDir: ""}
+	r := Repository{root: repoPath, gitDir: dotGitDir, hooksDir: "", objects: &objectReader{}}
 	return &r, nil
 }
 
@@ -0,0 +1,32 @@
+package revlist
+
+import "github.com/captainhook-go/captainhook/git/types"
+
+// Count prints the number of commits instead of the commit hashes
+func Count(g *types.Cmd) {
+	g.AddOption("--count")
+}
+
+// LeftRight marks which side of a symmetric difference a commit is reachable from
+func LeftRight(g *types.Cmd) {
+	g.AddOption("--left-right")
+}
+
+// Merges only lists merge commits
+func Merges(g *types.Cmd) {
+	g.AddOption("--merges")
+}
+
+// FromTo lists the commits reachable from 'to' but not from 'from'
+func FromTo(from, to string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption(from + ".." + to)
+	}
+}
+
+// SymmetricDifference lists the commits reachable from either 'left' or 'right' but not from both
+func SymmetricDifference(left, right string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption(left + "..." + right)
+	}
+}
@@ -0,0 +1,27 @@
+package revlist
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+func TestCountLeftRight(t *testing.T) {
+	g := types.NewCmd("rev-list")
+	g.AddOptions(LeftRight, Count, SymmetricDifference("main", "origin/main"))
+
+	if len(g.Options) < 4 {
+		t.Errorf("Options not added correctly")
+	}
+	if g.Options[1] != "--left-right" || g.Options[2] != "--count" || g.Options[3] != "main...origin/main" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
+
+func TestMergesFromTo(t *testing.T) {
+	g := types.NewCmd("rev-list")
+	g.AddOptions(Merges, FromTo("abc", "def"))
+
+	if g.Options[1] != "--merges" || g.Options[2] != "abc..def" {
+		t.Errorf("Wrong options: %v", g.Options)
+	}
+}
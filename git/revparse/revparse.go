@@ -11,3 +11,10 @@ func AbbrevRef(g *types.Cmd) {
 func ShowTopLevel(g *types.Cmd) {
 	g.AddOption("--show-toplevel")
 }
+
+// UpstreamOf returns the upstream branch of a given branch
+func UpstreamOf(branch string) func(g *types.Cmd) {
+	return func(g *types.Cmd) {
+		g.AddOption(branch + "@{upstream}")
+	}
+}
@@ -0,0 +1,8 @@
+package tag
+
+import "github.com/captainhook-go/captainhook/git/types"
+
+// List lists all tags
+func List(g *types.Cmd) {
+	g.AddOption("--list")
+}
@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	g := types.NewCmd("tag")
+	g.AddOptions(List)
+
+	if len(g.Options) < 2 {
+		t.Errorf("Option not added correctly")
+	}
+	if g.Options[1] != "--list" {
+		t.Errorf("Wrong option")
+	}
+}
@@ -5,6 +5,7 @@ type Commit struct {
 	Names   string
 	Date    string
 	Author  string
+	Email   string
 	Subject string
 	Body    string
 }
@@ -16,6 +17,7 @@ func CreateCommitFromXML(xml *XmlCommit) *Commit {
 		Names:   xml.Names,
 		Date:    xml.Date,
 		Author:  xml.Author,
+		Email:   xml.Email,
 		Subject: xml.Subject,
 		Body:    xml.Body,
 	}
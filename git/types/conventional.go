@@ -0,0 +1,121 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	conventionalHeader = regexp.MustCompile(`^(?P<type>[A-Za-z]+)(\((?P<scope>[^()]*)\))?(?P<breaking>!)?: (?P<description>.+)$`)
+	conventionalFooter = regexp.MustCompile(`^(?P<token>BREAKING CHANGE|BREAKING-CHANGE|` + trailerToken + `)(?P<separator>: | #)(?P<value>.*)$`)
+)
+
+// ConventionalFooter is a `token: value` or `token #value` line at the end of a conventional commit message
+type ConventionalFooter struct {
+	Token string
+	Value string
+}
+
+// IsBreakingChange indicates if the footer is a `BREAKING CHANGE` note
+func (f *ConventionalFooter) IsBreakingChange() bool {
+	return f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE"
+}
+
+// ConventionalCommit is a commit message following the conventional commits specification
+// https://www.conventionalcommits.org
+//
+//	type(scope)!: description
+//
+//	body
+//
+//	footer-token: value
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Footers     []*ConventionalFooter
+	breaking    bool
+}
+
+// IsBreaking returns true if the header is marked with `!` or there is a `BREAKING CHANGE` footer
+func (c *ConventionalCommit) IsBreaking() bool {
+	return c.breaking || len(c.BreakingNotes()) > 0
+}
+
+// BreakingNotes returns the values of all `BREAKING CHANGE` footers
+func (c *ConventionalCommit) BreakingNotes() []string {
+	var notes []string
+	for _, footer := range c.Footers {
+		if footer.IsBreakingChange() {
+			notes = append(notes, footer.Value)
+		}
+	}
+	return notes
+}
+
+// ParseConventionalCommit parses a commit message following the conventional commits specification
+// The error describes which part of the message is not following the specification.
+func ParseConventionalCommit(msg *CommitMessage) (*ConventionalCommit, error) {
+	subject := msg.Subject()
+	match := conventionalHeader.FindStringSubmatch(subject)
+	if match == nil {
+		return nil, errors.New("subject has to look like 'type(scope): description'")
+	}
+	if len(msg.Lines()) > 1 && strings.TrimSpace(msg.Lines()[1]) != "" {
+		return nil, errors.New("subject and body have to be separated by a blank line")
+	}
+	c := &ConventionalCommit{
+		Type:        match[conventionalHeader.SubexpIndex("type")],
+		Scope:       match[conventionalHeader.SubexpIndex("scope")],
+		Description: match[conventionalHeader.SubexpIndex("description")],
+		breaking:    match[conventionalHeader.SubexpIndex("breaking")] == "!",
+	}
+	// only check the scope group, the description may contain parentheses as well
+	scope := conventionalHeader.FindStringSubmatchIndex(subject)[2*conventionalHeader.SubexpIndex("scope")]
+	if scope >= 0 && c.Scope == "" {
+		return nil, errors.New("scope must not be empty, remove the parentheses or add a scope")
+	}
+
+	body, footers := splitFooters(msg.BodyLines())
+	c.Body = strings.TrimSpace(strings.Join(body, "\n"))
+	c.Footers = footers
+	return c, nil
+}
+
+// splitFooters separates the footers from the rest of the body
+// Footers are the last paragraph of the body if it starts with a footer token.
+// Lines not starting with a token belong to the value of the previous footer.
+func splitFooters(lines []string) ([]string, []*ConventionalFooter) {
+	start := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) == "" && i < len(lines)-1 {
+			start = i + 1
+			break
+		}
+	}
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	if start >= len(lines) || !conventionalFooter.MatchString(lines[start]) {
+		return lines, nil
+	}
+
+	var footers []*ConventionalFooter
+	for _, line := range lines[start:] {
+		match := conventionalFooter.FindStringSubmatch(line)
+		if match == nil {
+			if strings.TrimSpace(line) != "" {
+				last := footers[len(footers)-1]
+				last.Value = last.Value + "\n" + line
+			}
+			continue
+		}
+		footers = append(footers, &ConventionalFooter{
+			Token: match[conventionalFooter.SubexpIndex("token")],
+			Value: match[conventionalFooter.SubexpIndex("value")],
+		})
+	}
+	return lines[:start], footers
+}
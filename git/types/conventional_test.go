@@ -0,0 +1,58 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	msg := NewCommitMessage(
+		"feat(api)!: add token endpoint\n\nSome body text.\n\nReviewed-by: Jane\nBREAKING CHANGE: tokens expire\n  after one hour\nRefs #123",
+		"#",
+	)
+	c, err := ParseConventionalCommit(msg)
+	if err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+	if c.Type != "feat" || c.Scope != "api" || c.Description != "add token endpoint" {
+		t.Errorf("Wrong header, got type '%s' scope '%s' description '%s'", c.Type, c.Scope, c.Description)
+	}
+	if c.Body != "Some body text." {
+		t.Errorf("Wrong body, got '%s'", c.Body)
+	}
+	if len(c.Footers) != 3 {
+		t.Fatalf("Expected 3 footers, got %d", len(c.Footers))
+	}
+	if c.Footers[2].Token != "Refs" || c.Footers[2].Value != "123" {
+		t.Errorf("Wrong footer, got '%s' '%s'", c.Footers[2].Token, c.Footers[2].Value)
+	}
+	notes := c.BreakingNotes()
+	if !c.IsBreaking() || len(notes) != 1 || notes[0] != "tokens expire\n  after one hour" {
+		t.Errorf("Wrong breaking notes, got %v", notes)
+	}
+}
+
+func TestParseConventionalCommitWithoutFooters(t *testing.T) {
+	c, err := ParseConventionalCommit(NewCommitMessage("fix: foo\n\nJust a body.", "#"))
+	if err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+	if c.Scope != "" || c.IsBreaking() || len(c.Footers) != 0 || c.Body != "Just a body." {
+		t.Errorf("Unexpected parse result: %+v", c)
+	}
+}
+
+func TestParseConventionalCommitInvalid(t *testing.T) {
+	for _, raw := range []string{"add token endpoint", "feat(): foo", "feat:foo", "fix: foo\nbar"} {
+		if _, err := ParseConventionalCommit(NewCommitMessage(raw, "#")); err == nil {
+			t.Errorf("Message '%s' should be invalid", raw)
+		}
+	}
+}
+
+func TestParseConventionalCommitWithParenthesesInDescription(t *testing.T) {
+	for _, raw := range []string{"fix: call Close() on exit", "feat(api): add foo()"} {
+		if _, err := ParseConventionalCommit(NewCommitMessage(raw, "#")); err != nil {
+			t.Errorf("Message '%s' should be valid, got: %s", raw, err.Error())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package types
+
+const (
+	DiffLineContext = 0
+	DiffLineAdded   = 1
+	DiffLineRemoved = 2
+
+	FileStatusAdded    = "A"
+	FileStatusCopied   = "C"
+	FileStatusDeleted  = "D"
+	FileStatusModified = "M"
+	FileStatusRenamed  = "R"
+)
+
+// Diff represents the parsed output of a `git diff` command
+type Diff struct {
+	Files []*FileDiff
+}
+
+// File returns the FileDiff for a given path or nil if the file is not part of the diff
+func (d *Diff) File(path string) *FileDiff {
+	for _, file := range d.Files {
+		if file.Path() == path {
+			return file
+		}
+	}
+	return nil
+}
+
+// Paths returns the paths of all files in the diff
+func (d *Diff) Paths() []string {
+	var paths []string
+	for _, file := range d.Files {
+		paths = append(paths, file.Path())
+	}
+	return paths
+}
+
+// FileDiff contains all changes made to a single file
+// For added files OldPath is empty, for deleted files NewPath is empty.
+type FileDiff struct {
+	Status   string
+	OldPath  string
+	NewPath  string
+	IsBinary bool
+	Hunks    []*Hunk
+}
+
+// Path returns the current path of the file or the old path if the file got deleted
+func (f *FileDiff) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// AddedLines returns all lines added to the file
+func (f *FileDiff) AddedLines() []*DiffLine {
+	return f.linesOfType(DiffLineAdded)
+}
+
+// RemovedLines returns all lines removed from the file
+func (f *FileDiff) RemovedLines() []*DiffLine {
+	return f.linesOfType(DiffLineRemoved)
+}
+
+func (f *FileDiff) linesOfType(lineType int) []*DiffLine {
+	var lines []*DiffLine
+	for _, hunk := range f.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Type == lineType {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// Hunk is a block of changes introduced by a `@@ -1,2 +1,3 @@` header
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Section  string
+	Lines    []*DiffLine
+}
+
+// DiffLine is a single line of a Hunk
+// Added lines only have a NewNumber, removed lines only have an OldNumber.
+type DiffLine struct {
+	Type      int
+	Content   string
+	OldNumber int
+	NewNumber int
+}
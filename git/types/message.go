@@ -5,6 +5,21 @@ import (
 	"strings"
 )
 
+// NoCommentChar can be used for messages of existing commits
+// Git already removed the comments, and since commit messages can't contain NUL bytes no line is treated as one.
+const NoCommentChar = "\x00"
+
+// mergeSubjectPrefixes are the beginnings of the generated merge commit subjects
+var mergeSubjectPrefixes = []string{
+	"Merge branch '",
+	"Merge branches '",
+	"Merge remote-tracking branch '",
+	"Merge remote-tracking branches '",
+	"Merge tag '",
+	"Merge commit '",
+	"Merge pull request #",
+}
+
 // CommitMessage represents a git commit message
 // You can access the message's Subject and Body
 type CommitMessage struct {
@@ -72,14 +87,34 @@ func (m *CommitMessage) IsSquash() bool {
 	return strings.HasPrefix(m.raw, "squash!")
 }
 
+// IsMerge indicates if a commit is a merge commit using the default git merge message
+// The subjects generated by git and by GitHub pull requests are detected.
+func (m *CommitMessage) IsMerge() bool {
+	for _, prefix := range mergeSubjectPrefixes {
+		if strings.HasPrefix(m.Subject(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevert indicates if a commit is a commit created by `git revert`
+func (m *CommitMessage) IsRevert() bool {
+	return strings.HasPrefix(m.Subject(), "Revert \"")
+}
+
 // IsEmpty returns true if the commit message does not have any content
 func (m *CommitMessage) IsEmpty() bool {
 	return strings.TrimSpace(m.Message()) == ""
 }
 
 // NewCommitMessage is the CommitMessage struct constructor
+// If the comment character is 'auto' the character git used is detected from the message.
 func NewCommitMessage(msg string, commentChar string) *CommitMessage {
 	rawLines := io.SplitLines(msg)
+	if commentChar == "auto" {
+		commentChar = detectCommentChar(rawLines)
+	}
 
 	m := CommitMessage{
 		commentChar:  commentChar,
@@ -99,6 +134,42 @@ func NewCommitMessageFromFile(file, commentChar string) (*CommitMessage, error)
 	return NewCommitMessage(string(data), commentChar), nil
 }
 
+// detectCommentChar finds the comment character git chose for 'core.commentChar=auto'
+// Git adds its comments at the end of the message, so the scissors line or the last line tells
+// which character got used. Without comments the first candidate no line starts with is used.
+func detectCommentChar(rawLines []string) string {
+	candidates := "#;@!$%^&|:"
+	for _, line := range rawLines {
+		if line != "" && strings.Contains(line, "------------------------ >8 ------------------------") {
+			return line[:1]
+		}
+	}
+	for i := len(rawLines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(rawLines[i]) == "" {
+			continue
+		}
+		// git comment lines are the character followed by a space or nothing at all
+		line := rawLines[i]
+		if strings.Contains(candidates, line[:1]) && (len(line) == 1 || line[1] == ' ') {
+			return line[:1]
+		}
+		break
+	}
+	for _, candidate := range strings.Split(candidates, "") {
+		used := false
+		for _, line := range rawLines {
+			if strings.HasPrefix(line, candidate) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return candidate
+		}
+	}
+	return "#"
+}
+
 // extractContentLines finds all none comment lines in a commit message
 func extractContentLines(rawLines []string, commentChar string) []string {
 	var lines []string
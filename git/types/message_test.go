@@ -12,3 +12,37 @@ func TestSubject(t *testing.T) {
 		t.Errorf("Wrong subject, expected '" + expected + "' got '" + m.Subject() + "'")
 	}
 }
+
+func TestAutoCommentChar(t *testing.T) {
+	m := NewCommitMessage("Foo bar\n\n# not a comment\n\n; Please enter the commit message\n; for your changes.", "auto")
+	if m.CommentChar() != ";" {
+		t.Errorf("Comment char should be ';', got '%s'", m.CommentChar())
+	}
+	if len(m.Lines()) != 4 || m.Lines()[2] != "# not a comment" {
+		t.Errorf("Only the ';' lines should be comments, got %v", m.Lines())
+	}
+
+	m = NewCommitMessage("Foo bar\n\n#123 fixed", "auto")
+	if m.CommentChar() != ";" || len(m.Lines()) != 3 {
+		t.Errorf("Message without comments should keep all lines, got %v", m.Lines())
+	}
+}
+
+func TestIsMerge(t *testing.T) {
+	tests := map[string]bool{
+		"Merge branch 'feature/foo'":                          true,
+		"Merge branch 'main' of github.com:foo/bar":           true,
+		"Merge branches 'a' and 'b'":                          true,
+		"Merge remote-tracking branch 'origin/main'":          true,
+		"Merge tag 'v1.0.0'":                                  true,
+		"Merge commit 'abc1234'":                              true,
+		"Merge pull request #42 from foo/bar":                 true,
+		"Merge duplicate config loaders":                      false,
+		"Merge the user settings into the global config file": false,
+	}
+	for subject, expected := range tests {
+		if NewCommitMessage(subject, "#").IsMerge() != expected {
+			t.Errorf("Wrong merge detection for '%s', expected %t", subject, expected)
+		}
+	}
+}
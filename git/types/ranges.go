@@ -4,6 +4,7 @@ type Ref struct {
 	id     string
 	hash   string
 	branch string
+	tag    string
 }
 
 func (r *Ref) Id() string {
@@ -18,6 +19,16 @@ func (r *Ref) Branch() string {
 	return r.branch
 }
 
+// IsTag tells you if the ref is a tag, tags don't have a branch
+func (r *Ref) IsTag() bool {
+	return r.tag != ""
+}
+
+// Tag returns the name of the tag e.g. 'v1.0.0'
+func (r *Ref) Tag() string {
+	return r.tag
+}
+
 func NewRef(id, hash, branch string) *Ref {
 	r := Ref{
 		id:     id,
@@ -27,6 +38,16 @@ func NewRef(id, hash, branch string) *Ref {
 	return &r
 }
 
+// NewTagRef creates a Ref pointing to a tag
+func NewTagRef(id, hash, tag string) *Ref {
+	r := Ref{
+		id:   id,
+		hash: hash,
+		tag:  tag,
+	}
+	return &r
+}
+
 // Range is used to represent start and endpoints of change-sets
 type Range struct {
 	from *Ref
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trailerToken is the format git accepts for trailer keys
+const trailerToken = `[A-Za-z0-9-]+`
+
+// trailerLine requires whitespace or the end of the line after the colon, so URLs are no trailers
+var trailerLine = regexp.MustCompile(`^(?P<key>` + trailerToken + `):(?:\s+(?P<value>.*))?$`)
+
+// Trailer is a `Key: value` line at the end of a commit message like `Signed-off-by: Name <mail>`
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+func (t *Trailer) String() string {
+	return t.Key + ": " + t.Value
+}
+
+// Trailers returns all trailers of the commit message in order of appearance
+// Trailers are the last paragraph of the message if every line of that paragraph is a trailer.
+// Lines starting with whitespace continue the value of the previous trailer.
+func (m *CommitMessage) Trailers() []*Trailer {
+	start, end := m.trailerBlock()
+	if start < 0 {
+		return nil
+	}
+	trailers, _ := parseTrailers(m.contentLines[start:end])
+	return trailers
+}
+
+// TrailerValues returns the values of all trailers with the given key
+// Keys are compared case-insensitive, so `Signed-off-by` and `signed-off-by` are the same.
+func (m *CommitMessage) TrailerValues(key string) []string {
+	var values []string
+	for _, trailer := range m.Trailers() {
+		if strings.EqualFold(trailer.Key, key) {
+			values = append(values, trailer.Value)
+		}
+	}
+	return values
+}
+
+// HasTrailer checks if the commit message has a trailer with the given key and value
+func (m *CommitMessage) HasTrailer(key, value string) bool {
+	for _, v := range m.TrailerValues(key) {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// LinesWithoutTrailers returns all content lines except the trailer block
+func (m *CommitMessage) LinesWithoutTrailers() []string {
+	start, _ := m.trailerBlock()
+	if start < 0 {
+		return m.contentLines
+	}
+	return m.contentLines[:start]
+}
+
+// WithTrailer returns a new CommitMessage with the trailer added to the trailer block
+// If the message already contains the trailer the message is returned unchanged.
+// Comment lines stay in place, the trailer is added after the last content line.
+func (m *CommitMessage) WithTrailer(key, value string) *CommitMessage {
+	if m.HasTrailer(key, value) {
+		return m
+	}
+	trailer := (&Trailer{Key: key, Value: value}).String()
+
+	// find the last none comment line in the raw message
+	last := -1
+	for i, line := range m.rawLines {
+		if strings.HasPrefix(line, m.commentChar) {
+			if strings.Contains(line, "------------------------ >8 ------------------------") {
+				break
+			}
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			last = i
+		}
+	}
+
+	var insert []string
+	switch {
+	case last < 0:
+		// empty message, keep the first line free for the subject
+		insert = []string{"", "", trailer}
+	case m.hasTrailerBlock():
+		insert = []string{trailer}
+	default:
+		insert = []string{"", trailer}
+	}
+
+	lines := append([]string{}, m.rawLines[:last+1]...)
+	lines = append(lines, insert...)
+	lines = append(lines, m.rawLines[last+1:]...)
+	return NewCommitMessage(strings.Join(lines, "\n")+"\n", m.commentChar)
+}
+
+func (m *CommitMessage) hasTrailerBlock() bool {
+	start, _ := m.trailerBlock()
+	return start > -1
+}
+
+// trailerBlock returns the start and end index of the trailer paragraph in the content lines
+// If there is no trailer paragraph -1 is returned as start index.
+func (m *CommitMessage) trailerBlock() (int, int) {
+	end := len(m.contentLines)
+	for end > 0 && strings.TrimSpace(m.contentLines[end-1]) == "" {
+		end--
+	}
+	start := end
+	for start > 0 && strings.TrimSpace(m.contentLines[start-1]) != "" {
+		start--
+	}
+	// the subject can never be a trailer
+	if start == 0 || start == end {
+		return -1, -1
+	}
+	if _, ok := parseTrailers(m.contentLines[start:end]); !ok {
+		return -1, -1
+	}
+	return start, end
+}
+
+// parseTrailers parses a paragraph into trailers
+// If the paragraph contains any line that is not a trailer or a folded value false is returned.
+func parseTrailers(lines []string) ([]*Trailer, bool) {
+	var trailers []*Trailer
+	for _, line := range lines {
+		if len(trailers) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			last := trailers[len(trailers)-1]
+			last.Value = last.Value + " " + strings.TrimSpace(line)
+			continue
+		}
+		match := trailerLine.FindStringSubmatch(line)
+		if match == nil {
+			return nil, false
+		}
+		trailers = append(trailers, &Trailer{
+			Key:   match[trailerLine.SubexpIndex("key")],
+			Value: strings.TrimSpace(match[trailerLine.SubexpIndex("value")]),
+		})
+	}
+	return trailers, true
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestTrailers(t *testing.T) {
+	m := NewCommitMessage(
+		"Foo bar\n\nSome body: text\n\nSigned-off-by: Jane <jane@example.com>\nRefs: #1\nrefs: #2\nCo-authored-by: John\n  <john@example.com>\n# comment",
+		"#",
+	)
+	trailers := m.Trailers()
+	if len(trailers) != 4 {
+		t.Fatalf("Expected 4 trailers, got %d", len(trailers))
+	}
+	if trailers[3].Value != "John <john@example.com>" {
+		t.Errorf("Folded value not joined, got '%s'", trailers[3].Value)
+	}
+	if refs := m.TrailerValues("Refs"); len(refs) != 2 {
+		t.Errorf("Expected 2 refs, got %v", refs)
+	}
+	if !m.HasTrailer("signed-off-by", "Jane <jane@example.com>") {
+		t.Errorf("Sign off should be found")
+	}
+}
+
+func TestTrailersNoTrailerBlock(t *testing.T) {
+	for _, raw := range []string{"Foo: bar", "Foo bar\n\nSome body\nRefs: #1", "Foo bar\n\nhttp://example.com"} {
+		if trailers := NewCommitMessage(raw, "#").Trailers(); len(trailers) != 0 {
+			t.Errorf("No trailers expected for '%s', got %d", raw, len(trailers))
+		}
+	}
+}
+
+func TestWithTrailer(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{"Foo bar\n# comment\n", "Foo bar\n\nRefs: #1\n# comment\n"},
+		{"Foo bar\n\nSigned-off-by: Jane\n", "Foo bar\n\nSigned-off-by: Jane\nRefs: #1\n"},
+		{"Foo bar\n\nRefs: #1\n", "Foo bar\n\nRefs: #1\n"},
+		{"# comment\n", "\n\nRefs: #1\n# comment\n"},
+	}
+	for _, test := range tests {
+		m := NewCommitMessage(test.raw, "#").WithTrailer("Refs", "#1")
+		if m.Raw() != test.expected {
+			t.Errorf("Wrong message, expected %q got %q", test.expected, m.Raw())
+		}
+	}
+}
@@ -12,7 +12,8 @@ import (
 //	  <hash>55d061</hash>
 //	  <names><![CDATA[head]]></names>
 //	  <date>2023-04-23</date>
-//	  <author><![CDATA[Sebastian Feldmann <sf@sebastian-feldmann.info>]]></author>
+//	  <author><![CDATA[Sebastian Feldmann]]></author>
+//	  <email><![CDATA[sf@sebastian-feldmann.info]]></email>
 //	  <subject><![CDATA[Fix example docs]]></subject>
 //	  <body><![CDATA[]]></body>
 //	</commit>
@@ -28,6 +29,7 @@ type XmlCommit struct {
 	Names   string   `xml:"names"`
 	Date    string   `xml:"date"`
 	Author  string   `xml:"author"`
+	Email   string   `xml:"email"`
 	Subject string   `xml:"subject"`
 	Body    string   `xml:"body"`
 }
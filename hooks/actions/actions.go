@@ -6,10 +6,14 @@ import (
 	"github.com/captainhook-go/captainhook/git"
 	"github.com/captainhook-go/captainhook/hooks"
 	"github.com/captainhook-go/captainhook/hooks/actions/branch"
+	"github.com/captainhook-go/captainhook/hooks/actions/commit"
 	"github.com/captainhook-go/captainhook/hooks/actions/debug"
 	"github.com/captainhook-go/captainhook/hooks/actions/file"
+	"github.com/captainhook-go/captainhook/hooks/actions/interaction"
 	"github.com/captainhook-go/captainhook/hooks/actions/message"
 	"github.com/captainhook-go/captainhook/hooks/actions/notify"
+	"github.com/captainhook-go/captainhook/hooks/actions/remote"
+	"github.com/captainhook-go/captainhook/hooks/actions/tag"
 	"github.com/captainhook-go/captainhook/io"
 	"strings"
 )
@@ -19,6 +23,11 @@ var (
 		"branch": {
 			"ensurenaming":                       branch.NewEnsureNaming,
 			"preventpushoffixupandsquashcommits": branch.NewPreventPushOfFixupAndSquashCommits,
+			"protect":                            branch.NewProtect,
+			"requireuptodate":                    branch.NewRequireUpToDate,
+		},
+		"commit": {
+			"authorpolicy": commit.NewAuthorPolicy,
 		},
 		"debug": {
 			"fail":    debug.NewFail,
@@ -26,21 +35,43 @@ var (
 		},
 		"file": {
 			"blocksecrets":        file.NewBlockSecrets,
+			"changedtogether":     file.NewChangedTogether,
 			"doesnotcontainregex": file.NewDoesNotContainRegex,
 			"isnotempty":          file.NewIsNotEmpty,
+			"isvalidsyntax":       file.NewIsValidSyntax,
+			"licenseheader":       file.NewLicenseHeaderCheck,
 			"maxsize":             file.NewMaxSize,
+			"ownership":           file.NewOwnership,
+		},
+		"interaction": {
+			"confirm": interaction.NewConfirm,
 		},
 		"message": {
-			"injectissuekeyfrombranch": message.NewInjectIssueKeyFromBranch,
-			"cacheonfail":              message.NewCacheOnFail,
-			"mustfollowbeamsrules":     message.NewBeamsRules,
-			"mustcontainsregex":        message.NewContainsRegex,
-			"preparefromfile":          message.NewPrepareFromFile,
-			"prepare":                  message.NewPrepare,
+			"addtrailer":                    message.NewAddTrailer,
+			"injectcoauthors":               message.NewInjectCoAuthors,
+			"injectissuekeyfrombranch":      message.NewInjectIssueKeyFromBranch,
+			"issuekeyexists":                message.NewIssueKeyExists,
+			"cacheonfail":                   message.NewCacheOnFail,
+			"mustfollowbeamsrules":          message.NewBeamsRules,
+			"mustfollowrules":               message.NewMustFollowRules,
+			"mustfollowconventionalcommits": message.NewMustFollowConventionalCommits,
+			"mustcontainsregex":             message.NewContainsRegex,
+			"preparefromfile":               message.NewPrepareFromFile,
+			"preparefromtemplate":           message.NewPrepareFromTemplate,
+			"requiretrailers":               message.NewRequireTrailers,
+			"spellcheck":                    message.NewSpellCheck,
+			"normalize":                     message.NewNormalize,
+			"prepare":                       message.NewPrepare,
 		},
 		"notify": {
 			"gitnotify": notify.NewGitNotify,
 		},
+		"remote": {
+			"policy": remote.NewPolicy,
+		},
+		"tag": {
+			"policy": tag.NewPolicy,
+		},
 	}
 )
 
@@ -57,7 +57,11 @@ func (a *PreventPushOfFixupAndSquashCommits) Run(action *configuration.Action) e
 		commits := a.blockedCommits(aRange.From().Id(), aRange.To().Id())
 
 		if len(commits) > 0 {
-			return errors.New(a.createFailureMessage(commits, aRange.From().Branch()))
+			name := aRange.From().Branch()
+			if aRange.From().IsTag() {
+				name = aRange.From().Tag()
+			}
+			return errors.New(a.createFailureMessage(commits, name))
 		}
 	}
 	return nil
@@ -0,0 +1,103 @@
+package branch
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"path"
+	"strings"
+)
+
+// Protect prevents direct commits, pushes, force-pushes and deletions of protected branches.
+// Branches are configured as glob patterns like 'release/*'.
+// Direct commits are checked in 'pre-commit' hooks, everything else in 'pre-push' hooks.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Branch.Protect",
+//	  "options": {
+//	    "branches": ["main", "release/*"],
+//	    "block-commit": true,
+//	    "block-push": false,
+//	    "block-force-push": true,
+//	    "block-delete": true
+//	  }
+//	}
+type Protect struct {
+	hookBundle *hooks.HookBundle
+	branches   []string
+}
+
+func (a *Protect) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *Protect) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("protect branches", true, io.VERBOSE)
+
+	a.branches = action.Options().AsSliceOfStrings("branches")
+	if len(a.branches) == 0 {
+		a.branches = []string{"main", "master"}
+	}
+
+	if a.hookBundle.AppIO.Argument(info.ArgCommand, "") == info.PreCommit {
+		return a.checkCommit(action.Options())
+	}
+	return a.checkPush(action.Options())
+}
+
+func (a *Protect) checkCommit(options *configuration.Options) error {
+	branch := a.hookBundle.Repo.BranchName()
+	if !options.AsBool("block-commit", true) || !a.isProtected(branch) {
+		return nil
+	}
+	return fmt.Errorf("direct commits to protected branch '%s' are not allowed", branch)
+}
+
+func (a *Protect) checkPush(options *configuration.Options) error {
+	var problems []string
+	for _, ref := range input.PushRefs(a.hookBundle.AppIO) {
+		if !ref.IsBranch() || !a.isProtected(ref.Branch()) {
+			continue
+		}
+		branch := "<comment>" + ref.Branch() + "</comment>"
+		switch {
+		case ref.IsDeletion():
+			if options.AsBool("block-delete", true) {
+				problems = append(problems, "deleting "+branch+" is not allowed")
+			}
+		case options.AsBool("block-push", false):
+			problems = append(problems, "pushing to "+branch+" is not allowed")
+		case !ref.IsNew() && !a.hookBundle.Repo.IsAncestor(ref.RemoteHash, ref.LocalHash):
+			if options.AsBool("block-force-push", true) {
+				problems = append(problems, "force-pushing "+branch+" is not allowed")
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("protected branches:\n  - " + strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+func (a *Protect) isProtected(branch string) bool {
+	for _, pattern := range a.branches {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func NewProtect(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := Protect{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PreCommit, info.PrePush}),
+	}
+	return &a
+}
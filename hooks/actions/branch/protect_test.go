@@ -0,0 +1,52 @@
+package branch
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestProtectBlocksDirectCommits(t *testing.T) {
+	repo := test.CreateFakeRepo()
+	a := NewProtect(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*Protect)
+	a.branches = []string{"main", "release/*"}
+
+	if err := a.checkCommit(configuration.NewOptions(map[string]interface{}{})); err == nil {
+		t.Errorf("Commits to main should be blocked")
+	}
+	repo.SetBranch("release/1.0")
+	if err := a.checkCommit(configuration.NewOptions(map[string]interface{}{})); err == nil {
+		t.Errorf("Commits to release/1.0 should be blocked")
+	}
+	repo.SetBranch("feature/foo")
+	if err := a.checkCommit(configuration.NewOptions(map[string]interface{}{})); err != nil {
+		t.Errorf("Commits to feature branches should be allowed")
+	}
+}
+
+func TestProtectBlocksForcePushAndDeletion(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876\n" +
+		"(delete) 0000000 refs/heads/release/1.0 09876\n" +
+		"refs/heads/foo 12345 refs/heads/foo 09876\n"})
+	repo := test.CreateFakeRepo().SetIsAncestor(false)
+	a := NewProtect(inOut, test.CreateFakeConfig(), repo).(*Protect)
+	a.branches = []string{"main", "release/*"}
+
+	err := a.checkPush(configuration.NewOptions(map[string]interface{}{}))
+	if err == nil {
+		t.Fatalf("Force-push and deletion should be blocked")
+	}
+	if !strings.Contains(err.Error(), "force-pushing <comment>main</comment>") ||
+		!strings.Contains(err.Error(), "deleting <comment>release/1.0</comment>") ||
+		strings.Contains(err.Error(), "foo") {
+		t.Errorf("Wrong problems reported: %s", err.Error())
+	}
+
+	repo.SetIsAncestor(true)
+	err = a.checkPush(configuration.NewOptions(map[string]interface{}{"block-delete": false}))
+	if err != nil {
+		t.Errorf("Fast-forward pushes should be allowed, got: %s", err.Error())
+	}
+}
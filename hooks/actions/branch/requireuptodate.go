@@ -0,0 +1,121 @@
+package branch
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// RequireUpToDate prevents you from pushing branches that are too far behind their base branch.
+// If no 'base' is configured the upstream of your local 'main' or 'master' branch is used.
+// With 'linear-history' enabled merge commits in the pushed commits are blocked as well.
+// In 'warn' mode problems are only reported and the push is not blocked.
+// Only applicable for 'pre-push' hooks.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Branch.RequireUpToDate",
+//	  "options": {
+//	    "base": "origin/main",
+//	    "max-behind": 0,
+//	    "linear-history": true,
+//	    "mode": "block"
+//	  }
+//	}
+type RequireUpToDate struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *RequireUpToDate) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *RequireUpToDate) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("require branches to be up to date", true, io.VERBOSE)
+
+	err := a.check(action.Options())
+	if err != nil && action.Options().AsString("mode", "block") == "warn" {
+		a.hookBundle.AppIO.Write("<comment>"+err.Error()+"</comment>", true, io.NORMAL)
+		return nil
+	}
+	return err
+}
+
+func (a *RequireUpToDate) check(options *configuration.Options) error {
+	base, err := a.base(options)
+	if err != nil {
+		return err
+	}
+	maxBehind := options.AsInt("max-behind", 0)
+
+	var problems []string
+	for _, ref := range input.PushRefs(a.hookBundle.AppIO) {
+		if !ref.IsBranch() || ref.IsDeletion() {
+			continue
+		}
+		branch := "<comment>" + ref.Branch() + "</comment>"
+
+		_, behind, err := a.hookBundle.Repo.AheadBehind(ref.LocalHash, base)
+		if err != nil {
+			return err
+		}
+		if behind > maxBehind {
+			problems = append(problems, fmt.Sprintf("%s is %d commit(s) behind '%s'", branch, behind, base))
+		}
+
+		if options.AsBool("linear-history", false) {
+			merges, err := a.mergeCommits(ref, base)
+			if err != nil {
+				return err
+			}
+			if len(merges) > 0 {
+				problems = append(problems, fmt.Sprintf("%s contains %d merge commit(s)", branch, len(merges)))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("branches are not up to date:\n  - " + strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// base returns the configured base ref or the upstream of the local 'main' or 'master' branch
+func (a *RequireUpToDate) base(options *configuration.Options) (string, error) {
+	if base := options.AsString("base", ""); base != "" {
+		return base, nil
+	}
+	for _, branch := range []string{"main", "master"} {
+		if upstream := a.hookBundle.Repo.Upstream(branch); upstream != "" {
+			return upstream, nil
+		}
+	}
+	return "", errors.New("option 'base' is missing and no upstream branch could be found")
+}
+
+// mergeCommits returns the merge commits that get pushed
+// For new branches all commits since the merge-base with the base branch are checked.
+func (a *RequireUpToDate) mergeCommits(ref *input.PushRef, base string) ([]string, error) {
+	from := ref.RemoteHash
+	if ref.IsNew() {
+		mergeBase, err := a.hookBundle.Repo.MergeBase(ref.LocalHash, base)
+		if err != nil {
+			return nil, fmt.Errorf("could not find merge-base of '%s' and '%s'", ref.Branch(), base)
+		}
+		from = mergeBase
+	}
+	return a.hookBundle.Repo.MergeCommitsBetween(from, ref.LocalHash)
+}
+
+func NewRequireUpToDate(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := RequireUpToDate{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrePush}),
+	}
+	return &a
+}
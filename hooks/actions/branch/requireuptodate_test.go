@@ -0,0 +1,41 @@
+package branch
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestRequireUpToDateBlocksBranchesBehind(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetOptions(map[string]string{"input": "refs/heads/foo 12345 refs/heads/foo 0000000\n"})
+	repo := test.CreateFakeRepo().SetAheadBehind(2, 3).SetMergeCommits([]string{"abc1234"})
+	a := NewRequireUpToDate(inOut, test.CreateFakeConfig(), repo).(*RequireUpToDate)
+
+	err := a.check(configuration.NewOptions(map[string]interface{}{"base": "origin/main", "linear-history": true}))
+	if err == nil {
+		t.Fatalf("Branches behind base should be blocked")
+	}
+	if !strings.Contains(err.Error(), "3 commit(s) behind 'origin/main'") || !strings.Contains(err.Error(), "1 merge commit(s)") {
+		t.Errorf("Wrong problems reported: %s", err.Error())
+	}
+
+	err = a.check(configuration.NewOptions(map[string]interface{}{"base": "origin/main", "max-behind": 3}))
+	if err != nil {
+		t.Errorf("Branches within the threshold should be allowed, got: %s", err.Error())
+	}
+}
+
+func TestRequireUpToDateUsesUpstream(t *testing.T) {
+	repo := test.CreateFakeRepo()
+	a := NewRequireUpToDate(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*RequireUpToDate)
+
+	if _, err := a.base(configuration.NewOptions(map[string]interface{}{})); err == nil {
+		t.Errorf("Missing base and upstream should fail")
+	}
+	repo.SetUpstream("origin/main")
+	if base, _ := a.base(configuration.NewOptions(map[string]interface{}{})); base != "origin/main" {
+		t.Errorf("Upstream should be used as base, got: %s", base)
+	}
+}
@@ -0,0 +1,230 @@
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// AuthorPolicy makes sure you commit with the right identity.
+// In 'pre-commit' hooks the configured 'user.name' and 'user.email' are checked, in 'pre-push' hooks
+// the authors of all pushed commits. Emails have to match one of the allowed 'domains' or the 'email-regex'.
+// Names can be checked with a 'name-regex'.
+// Different rules can be configured per remote. The first 'remotes' entry with a 'url' regex matching the
+// remote url is used. If no entry matches, the top level rules apply.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Commit.AuthorPolicy",
+//	  "options": {
+//	    "domains": ["example.com"],
+//	    "name-regex": "^\\S+ \\S+",
+//	    "remotes": [
+//	      {"url": "github\\.com", "email-regex": "@users\\.noreply\\.github\\.com$"}
+//	    ]
+//	  }
+//	}
+type AuthorPolicy struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *AuthorPolicy) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *AuthorPolicy) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking author identity", true, io.VERBOSE)
+
+	url := a.remoteURL()
+	policy, err := selectPolicy(action.Options().All(), url)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		a.hookBundle.AppIO.Write("no author policy for remote '"+url+"'", true, io.VERBOSE)
+		return nil
+	}
+
+	if a.hookBundle.AppIO.Argument(info.ArgCommand, "") == info.PrePush {
+		return a.checkPushedCommits(policy)
+	}
+	return a.checkConfig(policy)
+}
+
+func (a *AuthorPolicy) checkConfig(policy *authorPolicy) error {
+	name := a.hookBundle.Repo.ConfigValue("user.name", "")
+	email := a.hookBundle.Repo.ConfigValue("user.email", "")
+
+	problems := policy.check(name, email)
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(
+		"your git identity does not follow the author policy:\n  - " + strings.Join(problems, "\n  - ") +
+			"\nfix it with:\n" + policy.fixCommands(),
+	)
+}
+
+func (a *AuthorPolicy) checkPushedCommits(policy *authorPolicy) error {
+	var out []string
+	for _, commit := range input.PushedCommits(a.hookBundle.AppIO, a.hookBundle.Repo) {
+		problems := policy.check(commit.Author, commit.Email)
+		if len(problems) > 0 {
+			out = append(out, " - <info>"+commit.Hash+"</info> "+commit.Author+" <"+commit.Email+">: "+
+				strings.Join(problems, ", "))
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return errors.New(
+		"commits with authors not following the author policy:\n" + strings.Join(out, "\n") +
+			"\nfix your identity with:\n" + policy.fixCommands() +
+			"\nand reset the author of your commits with:\n" +
+			"  git rebase -r <base> --exec \"git commit --amend --no-edit --reset-author\"",
+	)
+}
+
+// remoteURL returns the url of the remote you push to or the remote of the current branch
+func (a *AuthorPolicy) remoteURL() string {
+	if url := a.hookBundle.AppIO.Argument(info.ArgURL, ""); url != "" {
+		return url
+	}
+	repo := a.hookBundle.Repo
+	remote := repo.ConfigValue("branch."+repo.BranchName()+".remote", "origin")
+	return repo.ConfigValue("remote."+remote+".url", "")
+}
+
+// authorPolicy holds the identity rules for a remote
+type authorPolicy struct {
+	domains    []string
+	emailRegex *regexp.Regexp
+	nameRegex  *regexp.Regexp
+}
+
+// check returns a list of problems with a name and email
+func (p *authorPolicy) check(name, email string) []string {
+	var problems []string
+	if strings.TrimSpace(name) == "" {
+		problems = append(problems, "name is empty")
+	} else if p.nameRegex != nil && !p.nameRegex.MatchString(name) {
+		problems = append(problems, fmt.Sprintf("name '%s' does not match '%s'", name, p.nameRegex.String()))
+	}
+	if !p.allowsEmail(email) {
+		problems = append(problems, fmt.Sprintf("email '%s' is not allowed, use %s", email, p.allowedEmails()))
+	}
+	return problems
+}
+
+func (p *authorPolicy) allowsEmail(email string) bool {
+	if email == "" {
+		return false
+	}
+	if len(p.domains) == 0 && p.emailRegex == nil {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at >= 0 && slices.Contains(p.domains, strings.ToLower(email[at+1:])) {
+		return true
+	}
+	return p.emailRegex != nil && p.emailRegex.MatchString(email)
+}
+
+func (p *authorPolicy) allowedEmails() string {
+	var allowed []string
+	for _, domain := range p.domains {
+		allowed = append(allowed, "'@"+domain+"'")
+	}
+	if p.emailRegex != nil {
+		allowed = append(allowed, "'"+p.emailRegex.String()+"'")
+	}
+	if len(allowed) == 0 {
+		return "a valid email"
+	}
+	return strings.Join(allowed, " or ")
+}
+
+// fixCommands returns the git config commands to set a valid identity
+func (p *authorPolicy) fixCommands() string {
+	email := "you@example.com"
+	if len(p.domains) > 0 {
+		email = "you@" + p.domains[0]
+	}
+	return "  git config user.name \"Your Name\"\n" +
+		"  git config user.email \"" + email + "\""
+}
+
+// selectPolicy returns the policy for the given remote url
+// If no remote specific rules match and no top level rules are configured nil is returned.
+// All remote url regexes are validated before matching, so a broken entry is noticed right away.
+func selectPolicy(options map[string]interface{}, url string) (*authorPolicy, error) {
+	type remotePolicy struct {
+		url   *regexp.Regexp
+		rules map[string]interface{}
+	}
+	var remotePolicies []remotePolicy
+	if remotes, ok := options["remotes"].([]interface{}); ok {
+		for _, remote := range remotes {
+			rules, ok := remote.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("option 'remotes' has to be a list of objects")
+			}
+			pattern, _ := rules["url"].(string)
+			// an empty regex would match every remote
+			if pattern == "" {
+				return nil, errors.New("every 'remotes' entry needs a 'url' regex")
+			}
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid remote url regex '%s': %s", pattern, err.Error())
+			}
+			remotePolicies = append(remotePolicies, remotePolicy{url: r, rules: rules})
+		}
+	}
+	for _, remote := range remotePolicies {
+		if url != "" && remote.url.MatchString(url) {
+			return newAuthorPolicy(configuration.NewOptions(remote.rules))
+		}
+	}
+	top := configuration.NewOptions(options)
+	if len(top.AsSliceOfStrings("domains")) == 0 && top.AsString("email-regex", "") == "" &&
+		top.AsString("name-regex", "") == "" {
+		return nil, nil
+	}
+	return newAuthorPolicy(top)
+}
+
+func newAuthorPolicy(options *configuration.Options) (*authorPolicy, error) {
+	p := authorPolicy{}
+	for _, domain := range options.AsSliceOfStrings("domains") {
+		p.domains = append(p.domains, strings.ToLower(strings.TrimPrefix(domain, "@")))
+	}
+	var err error
+	if pattern := options.AsString("email-regex", ""); pattern != "" {
+		if p.emailRegex, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid email regex '%s': %s", pattern, err.Error())
+		}
+	}
+	if pattern := options.AsString("name-regex", ""); pattern != "" {
+		if p.nameRegex, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid name regex '%s': %s", pattern, err.Error())
+		}
+	}
+	return &p, nil
+}
+
+func NewAuthorPolicy(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := AuthorPolicy{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PreCommit, info.PrePush}),
+	}
+	return &a
+}
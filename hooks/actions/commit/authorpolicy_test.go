@@ -0,0 +1,88 @@
+package commit
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestSelectPolicyByRemote(t *testing.T) {
+	options := map[string]interface{}{
+		"domains": []interface{}{"example.com"},
+		"remotes": []interface{}{
+			map[string]interface{}{"url": "github\\.com", "email-regex": "@users\\.noreply\\.github\\.com$"},
+		},
+	}
+
+	policy, _ := selectPolicy(options, "git@github.com:foo/bar.git")
+	if !policy.allowsEmail("foo@users.noreply.github.com") || policy.allowsEmail("foo@example.com") {
+		t.Errorf("GitHub remotes should only allow noreply emails")
+	}
+	policy, _ = selectPolicy(options, "git@git.example.com:foo/bar.git")
+	if !policy.allowsEmail("foo@Example.com") || policy.allowsEmail("foo@gmail.com") {
+		t.Errorf("Other remotes should only allow example.com emails")
+	}
+	if policy, _ = selectPolicy(map[string]interface{}{}, "git@github.com:foo/bar.git"); policy != nil {
+		t.Errorf("Without rules no policy should be selected")
+	}
+}
+
+func TestSelectPolicyRejectsEmptyRemoteURL(t *testing.T) {
+	options := map[string]interface{}{
+		"remotes": []interface{}{
+			map[string]interface{}{"url": "github\\.com", "domains": []interface{}{"example.com"}},
+			map[string]interface{}{"url": "", "domains": []interface{}{"example.org"}},
+		},
+	}
+
+	if _, err := selectPolicy(options, "git@github.com:foo/bar.git"); err == nil {
+		t.Errorf("Remote rules without url regex should be rejected")
+	}
+}
+
+func TestAuthorPolicyChecksConfig(t *testing.T) {
+	repo := test.CreateFakeRepo().SetConfig(map[string]string{"user.name": "Foo Bar", "user.email": "foo@gmail.com"})
+	a := NewAuthorPolicy(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*AuthorPolicy)
+	policy := &authorPolicy{domains: []string{"example.com"}}
+
+	err := a.checkConfig(policy)
+	if err == nil {
+		t.Fatalf("Private emails should be blocked")
+	}
+	if !strings.Contains(err.Error(), "git config user.email \"you@example.com\"") {
+		t.Errorf("Error should show the fix, got: %s", err.Error())
+	}
+}
+
+func TestAuthorPolicyChecksPushedCommits(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876"})
+	repo := test.CreateFakeRepo().SetLog([]*types.Commit{
+		{Hash: "abc1234", Author: "Foo Bar", Email: "foo@example.com"},
+		{Hash: "def5678", Author: "Foo Bar", Email: "foo@gmail.com"},
+	})
+	a := NewAuthorPolicy(inOut, test.CreateFakeConfig(), repo).(*AuthorPolicy)
+	policy := &authorPolicy{domains: []string{"example.com"}}
+
+	err := a.checkPushedCommits(policy)
+	if err == nil {
+		t.Fatalf("Commits with private emails should be blocked")
+	}
+	if strings.Contains(err.Error(), "abc1234") || !strings.Contains(err.Error(), "def5678") {
+		t.Errorf("Only the commit with the private email should be reported, got: %s", err.Error())
+	}
+}
+
+func TestAuthorPolicyChecksCommitsOfNewBranches(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/foo 12345 refs/heads/foo 0000000000000000000000000000000000000000"})
+	repo := test.CreateFakeRepo().SetLog([]*types.Commit{{Hash: "def5678", Author: "Foo Bar", Email: "foo@gmail.com"}})
+	a := NewAuthorPolicy(inOut, test.CreateFakeConfig(), repo).(*AuthorPolicy)
+
+	if err := a.checkPushedCommits(&authorPolicy{domains: []string{"example.com"}}); err == nil {
+		t.Errorf("Commits of new branches should be checked")
+	}
+}
@@ -4,30 +4,45 @@ import (
 	"fmt"
 	"github.com/captainhook-go/captainhook/configuration"
 	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
 	"github.com/captainhook-go/captainhook/hooks"
 	"github.com/captainhook-go/captainhook/hooks/input"
 	"github.com/captainhook-go/captainhook/info"
 	"github.com/captainhook-go/captainhook/io"
-	"regexp"
+	"slices"
 )
 
 // BlockSecrets blocks commits if a file is containing a string matching any if the given regexes.
+// With 'only-added-lines' only the lines added by the commit or push are checked, so existing
+// secrets in legacy files do not block every commit touching them.
+// Besides the regex presets, random looking values assigned to variables named like 'password' or
+// 'api_key' can be detected by measuring their entropy.
+// In 'pre-push' hooks 'scan-commits' checks the added lines and the message of every pushed commit,
+// so secrets that got committed and deleted later on are detected as well.
+// Lines containing the 'allow-marker' are ignored, as well as all secrets accepted in the
+// baseline file. To create or update the baseline run `captainhook secrets-baseline`.
 //
 // Example configuration:
 //
 //	{
 //	  "run": "CaptainHook::File.BlockSecrets",
 //	  "options: {
-//	    "presets": ["Aws", "GitHub", "Stripe", "Google"],
+//	    "presets": ["Aws", "GitHub", "Stripe", "Google", "Slack", "PrivateKey", "Jwt", "Database"],
+//	    "preset-files": ["secrets-presets.yml"],
 //	    "blocked": ["pattern1", "pattern2"],
-//	    "allowed": ["patternA"]
+//	    "allowed": ["patternA"],
+//	    "entropy": true,
+//	    "entropy-threshold": 3.5,
+//	    "entropy-min-length": 20,
+//	    "only-added-lines": true,
+//	    "scan-commits": true,
+//	    "allow-marker": "captainhook:allow-secret",
+//	    "baseline": ".captainhook-secrets-baseline"
 //	  }
 //	}
 type BlockSecrets struct {
 	hookBundle *hooks.HookBundle
-	presets    []string
-	blocked    []string
-	allowed    []string
+	scanner    *SecretScanner
 }
 
 func (a *BlockSecrets) IsApplicableFor(hook string) bool {
@@ -37,62 +52,186 @@ func (a *BlockSecrets) IsApplicableFor(hook string) bool {
 func (a *BlockSecrets) Run(action *configuration.Action) error {
 	a.hookBundle.AppIO.Write("checking if file contains secrets", true, io.VERBOSE)
 
-	a.presets = action.Options().AsSliceOfStrings("presets")
-	a.blocked = action.Options().AsSliceOfStrings("blocked")
-	a.allowed = action.Options().AsSliceOfStrings("allowed")
+	scanner, err := NewSecretScanner(action.Options(), a.hookBundle.Repo.Path())
+	if err != nil {
+		return err
+	}
+	baseline, err := LoadSecretsBaseline(SecretsBaselinePath(action.Options(), a.hookBundle.Repo.Path()))
+	if err != nil {
+		return err
+	}
+	scanner.UseBaseline(baseline)
+	a.scanner = scanner
 
-	files, err := input.StagedOrChangedFiles(a.hookBundle.AppIO, a.hookBundle.Repo)
+	var findings []*SecretFinding
+	isPrePush := a.hookBundle.AppIO.Argument(info.ArgCommand, "") == info.PrePush
+	if isPrePush && action.Options().AsBool("scan-commits", false) {
+		findings, err = a.scanCommits()
+	} else if action.Options().AsBool("only-added-lines", false) {
+		findings, err = a.scanAddedLines()
+	} else {
+		findings, err = a.scanFiles()
+	}
 	if err != nil {
 		return err
 	}
-	for _, file := range files {
-		content, _ := io.ReadFile(file)
-		containsBlocked, err := a.containsBlockedString(string(content))
-		if containsBlocked {
-			return fmt.Errorf("file '%s' %s", file, err.Error())
+	if len(findings) == 0 {
+		return nil
+	}
+	for _, finding := range findings {
+		a.hookBundle.AppIO.Write("  - "+finding.String(), true, io.NORMAL)
+	}
+	if oldest := a.oldestCommits(findings); len(oldest) > 0 {
+		rebase := ""
+		for _, commit := range oldest {
+			rebase += "\n  git rebase -i " + a.rebaseBase(commit)
+		}
+		return fmt.Errorf(
+			"found %d possible secret(s) in the commits you are about to push\n"+
+				"removing a secret in a new commit is not enough, it is still part of the history\n"+
+				"rewrite the history before pushing, for example with%s\n"+
+				"and edit the listed commits, and rotate the secret if it ever left your machine",
+			len(findings),
+			rebase,
+		)
+	}
+	return fmt.Errorf(
+		"found %d possible secret(s)\n"+
+			"if a finding is a false positive add '%s' to the line\n"+
+			"or accept it with 'captainhook secrets-baseline --update'",
+		len(findings),
+		action.Options().AsString("allow-marker", DefaultAllowMarker),
+	)
+}
+
+// rebaseBase returns the rebase argument to edit the given commit
+// Root commits have no parent, so the whole history has to be rebased.
+func (a *BlockSecrets) rebaseBase(commit string) string {
+	if _, err := a.hookBundle.Repo.ObjectType(commit + "^"); err != nil {
+		return "--root"
+	}
+	return commit + "^"
+}
+
+// scanFiles scans the complete content of all staged or changed files
+// In 'pre-push' hooks every file is read from the ref that changed it.
+func (a *BlockSecrets) scanFiles() ([]*SecretFinding, error) {
+	fileRevisions, err := input.StagedOrChangedFileRevisions(a.hookBundle.AppIO, a.hookBundle.Repo)
+	if err != nil {
+		return nil, err
+	}
+	var findings []*SecretFinding
+	for _, f := range fileRevisions {
+		content, _ := a.hookBundle.Repo.FileContent(f.File, f.Revision)
+		// a file changed by multiple refs would otherwise report the same secret more than once
+		for _, finding := range a.scanner.ScanContent(f.File, string(content)) {
+			if !slices.ContainsFunc(findings, func(known *SecretFinding) bool { return *known == *finding }) {
+				findings = append(findings, finding)
+			}
 		}
 	}
-	return nil
+	return findings, nil
 }
 
-func (a *BlockSecrets) containsBlockedString(cnt string) (bool, error) {
-	regs, err := a.getRegexPatterns()
+// scanAddedLines only scans lines added in the staged changes or the pushed ranges
+func (a *BlockSecrets) scanAddedLines() ([]*SecretFinding, error) {
+	diffs, err := a.diffs()
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	var findings []*SecretFinding
+	for _, d := range diffs {
+		findings = append(findings, a.scanDiff(d)...)
 	}
-	for _, reg := range regs {
-		r, _ := regexp.Compile(reg)
-		matches := r.FindStringSubmatch(cnt)
-		if len(matches) > 0 && !a.isAllowed(matches[0]) {
-			return true, fmt.Errorf("contains %s", matches[0])
+	return findings, nil
+}
+
+func (a *BlockSecrets) scanDiff(d *types.Diff) []*SecretFinding {
+	var findings []*SecretFinding
+	for _, file := range d.Files {
+		for _, line := range file.AddedLines() {
+			findings = append(findings, a.scanner.ScanLine(file.Path(), line.NewNumber, line.Content)...)
 		}
 	}
-	return false, nil
+	return findings
 }
 
-func (a *BlockSecrets) isAllowed(blocked string) bool {
-	for _, reg := range a.allowed {
-		matched, readErr := regexp.MatchString(reg, blocked)
-		if readErr != nil {
-			return false
+// scanCommits scans the added lines and the message of every pushed commit
+func (a *BlockSecrets) scanCommits() ([]*SecretFinding, error) {
+	var findings []*SecretFinding
+	for _, commit := range input.PushedCommits(a.hookBundle.AppIO, a.hookBundle.Repo) {
+		d, err := a.hookBundle.Repo.CommitDiff(commit.Hash)
+		if err != nil {
+			return nil, err
 		}
-		if matched {
-			return true
+		commitFindings := a.scanDiff(d)
+		commitFindings = append(commitFindings, a.scanner.ScanContent("commit message", commit.Subject+"\n"+commit.Body)...)
+		for _, finding := range commitFindings {
+			finding.Commit = commit.Hash
 		}
+		findings = append(findings, commitFindings...)
 	}
-	return false
+	return findings, nil
 }
 
-func (a *BlockSecrets) getRegexPatterns() ([]string, error) {
-	regs := a.blocked
-	for _, name := range a.presets {
-		preset, err := GetPreset(name)
+// oldestCommits returns the hashes of the oldest commits containing a secret
+// A commit is one of the oldest if none of the other commits containing a secret is its ancestor.
+// If multiple branches get pushed there can be more than one.
+func (a *BlockSecrets) oldestCommits(findings []*SecretFinding) []string {
+	var commits []string
+	for _, finding := range findings {
+		if finding.Commit != "" && !slices.Contains(commits, finding.Commit) {
+			commits = append(commits, finding.Commit)
+		}
+	}
+	var oldest []string
+	for _, commit := range commits {
+		isOldest := true
+		for _, other := range commits {
+			if other != commit && a.hookBundle.Repo.IsAncestor(other, commit) {
+				isOldest = false
+				break
+			}
+		}
+		if isOldest {
+			oldest = append(oldest, commit)
+		}
+	}
+	return oldest
+}
+
+func (a *BlockSecrets) diffs() ([]*types.Diff, error) {
+	if a.hookBundle.AppIO.Argument(info.ArgCommand, "") == info.PreCommit {
+		d, err := a.hookBundle.Repo.StagedDiff()
 		if err != nil {
 			return nil, err
 		}
-		regs = append(regs, preset...)
+		return []*types.Diff{d}, nil
+	}
+	var diffs []*types.Diff
+	for _, ref := range input.PushRefs(a.hookBundle.AppIO) {
+		// deleted refs do not add any lines
+		if ref.IsDeletion() {
+			continue
+		}
+		if !ref.IsNew() {
+			d, err := a.hookBundle.Repo.DiffBetween(ref.RemoteHash, ref.LocalHash)
+			if err != nil {
+				return nil, err
+			}
+			diffs = append(diffs, d)
+			continue
+		}
+		// new refs have no remote state to compare to so every commit not on any remote gets checked
+		for _, commit := range a.hookBundle.Repo.CommitsNotOnRemotes(ref.LocalHash) {
+			d, err := a.hookBundle.Repo.CommitDiff(commit.Hash)
+			if err != nil {
+				return nil, err
+			}
+			diffs = append(diffs, d)
+		}
 	}
-	return regs, nil
+	return diffs, nil
 }
 
 func NewBlockSecrets(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
@@ -0,0 +1,103 @@
+package file
+
+import (
+	"errors"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultSecretsBaseline = ".captainhook-secrets-baseline"
+)
+
+// SecretsBaseline is a list of accepted secret fingerprints
+// It is used to accept existing findings like fake keys in test fixtures.
+// Every line of the baseline file contains a fingerprint followed by the path the secret was found in.
+//
+//	# CaptainHook secrets baseline
+//	9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 test/fixtures/aws.json
+type SecretsBaseline struct {
+	fingerprints map[string]string
+}
+
+// Contains tells you if a finding is accepted by the baseline
+func (b *SecretsBaseline) Contains(finding *SecretFinding) bool {
+	_, ok := b.fingerprints[finding.Fingerprint()]
+	return ok
+}
+
+// Add accepts a finding
+func (b *SecretsBaseline) Add(finding *SecretFinding) {
+	b.fingerprints[finding.Fingerprint()] = finding.Path
+}
+
+// Size returns the number of accepted findings
+func (b *SecretsBaseline) Size() int {
+	return len(b.fingerprints)
+}
+
+// Save writes the baseline to a file sorted by path
+func (b *SecretsBaseline) Save(path string) error {
+	var fingerprints []string
+	for fingerprint := range b.fingerprints {
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	sort.Slice(fingerprints, func(i, j int) bool {
+		a, c := b.fingerprints[fingerprints[i]], b.fingerprints[fingerprints[j]]
+		if a == c {
+			return fingerprints[i] < fingerprints[j]
+		}
+		return a < c
+	})
+
+	lines := []string{"# CaptainHook secrets baseline"}
+	for _, fingerprint := range fingerprints {
+		lines = append(lines, fingerprint+" "+b.fingerprints[fingerprint])
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// NewSecretsBaseline creates an empty baseline
+func NewSecretsBaseline() *SecretsBaseline {
+	return &SecretsBaseline{fingerprints: map[string]string{}}
+}
+
+// LoadSecretsBaseline reads a baseline file
+// If the file does not exist an empty baseline is returned.
+func LoadSecretsBaseline(path string) (*SecretsBaseline, error) {
+	b := NewSecretsBaseline()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return b, nil
+		}
+		return nil, err
+	}
+	for _, line := range io.SplitLines(string(content)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, " ", 2)
+		file := ""
+		if len(parts) == 2 {
+			file = parts[1]
+		}
+		b.fingerprints[parts[0]] = file
+	}
+	return b, nil
+}
+
+// SecretsBaselinePath returns the path to the baseline configured in the action options
+// Relative paths are relative to the repository root.
+func SecretsBaselinePath(options *configuration.Options, root string) string {
+	path := options.AsString("baseline", DefaultSecretsBaseline)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
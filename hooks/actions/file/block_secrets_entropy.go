@@ -0,0 +1,61 @@
+package file
+
+import (
+	"math"
+	"regexp"
+)
+
+var (
+	// suspiciousAssignment matches values assigned to variables with names hinting at secrets
+	suspiciousAssignment = regexp.MustCompile(
+		"(?i)[a-z0-9_.\\-]*(secret|token|passw(or)?d|pwd|api[_\\-]?key|access[_\\-]?key|private[_\\-]?key|auth|credentials?)[a-z0-9_.\\-]*" +
+			OptQuote + Connect + OptQuote + "(?P<value>[A-Za-z0-9+/=_\\-.~]+)",
+	)
+	suspiciousValueIndex = suspiciousAssignment.SubexpIndex("value")
+)
+
+// EntropyDetector finds random looking strings assigned to variables with suspicious names
+// Randomness is measured with the Shannon entropy in bits per character. Random base64
+// strings usually score above 4, hex strings above 3.5 while natural words stay below 3.
+type EntropyDetector struct {
+	threshold float64
+	minLength int
+}
+
+// Find returns all suspicious values of a line
+func (d *EntropyDetector) Find(line string) []string {
+	var found []string
+	for _, match := range suspiciousAssignment.FindAllStringSubmatch(line, -1) {
+		value := match[suspiciousValueIndex]
+		if len(value) < d.minLength {
+			continue
+		}
+		if ShannonEntropy(value) >= d.threshold {
+			found = append(found, value)
+		}
+	}
+	return found
+}
+
+// ShannonEntropy calculates the entropy of a string in bits per character
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	length := 0
+	for _, r := range s {
+		counts[r]++
+		length++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func NewEntropyDetector(threshold float64, minLength int) *EntropyDetector {
+	return &EntropyDetector{threshold: threshold, minLength: minLength}
+}
@@ -0,0 +1,30 @@
+package file
+
+import (
+	"testing"
+)
+
+func TestEntropyDetectorFindsRandomValue(t *testing.T) {
+	d := NewEntropyDetector(DefaultEntropyThreshold, DefaultEntropyMinLength)
+
+	found := d.Find(`db_password = "x8Kq2LmP9vRt4WzY7bNc3JhF"`)
+	if len(found) != 1 || found[0] != "x8Kq2LmP9vRt4WzY7bNc3JhF" {
+		t.Errorf("Random password should be detected, got: %v", found)
+	}
+}
+
+func TestEntropyDetectorIgnoresLowEntropy(t *testing.T) {
+	d := NewEntropyDetector(DefaultEntropyThreshold, DefaultEntropyMinLength)
+
+	if found := d.Find(`password = "aaaaaaaaaaaaaaaaaaaaaaaa"`); len(found) != 0 {
+		t.Errorf("Low entropy values should be ignored, got: %v", found)
+	}
+}
+
+func TestEntropyDetectorIgnoresUnsuspiciousNames(t *testing.T) {
+	d := NewEntropyDetector(DefaultEntropyThreshold, DefaultEntropyMinLength)
+
+	if found := d.Find(`checksum = "x8Kq2LmP9vRt4WzY7bNc3JhF"`); len(found) != 0 {
+		t.Errorf("Values of unsuspicious variables should be ignored, got: %v", found)
+	}
+}
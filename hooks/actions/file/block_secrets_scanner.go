@@ -0,0 +1,159 @@
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultAllowMarker      = "captainhook:allow-secret"
+	DefaultEntropyThreshold = 3.5
+	DefaultEntropyMinLength = 20
+)
+
+// SecretFinding is a possible secret found in a file
+// If the secret was found while scanning the history Commit contains the commit hash.
+type SecretFinding struct {
+	Commit string
+	Path   string
+	Line   int
+	Secret string
+}
+
+// Fingerprint identifies a finding independent of its line number
+// This way baseline entries stay valid if lines are added or removed above the secret.
+func (f *SecretFinding) Fingerprint() string {
+	hash := sha256.Sum256([]byte(f.Path + ":" + f.Secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func (f *SecretFinding) String() string {
+	location := fmt.Sprintf("%s:%d", f.Path, f.Line)
+	if f.Commit != "" {
+		location = "<info>" + f.Commit + "</info> " + location
+	}
+	return location + " contains " + f.Secret
+}
+
+// SecretScanner searches lines of text for strings matching the blocked patterns
+// Lines containing the allow marker and findings listed in the baseline are ignored.
+type SecretScanner struct {
+	blocked  []*regexp.Regexp
+	allowed  []*regexp.Regexp
+	entropy  *EntropyDetector
+	marker   string
+	baseline *SecretsBaseline
+}
+
+// UseBaseline makes the scanner ignore all findings accepted in the baseline
+func (s *SecretScanner) UseBaseline(baseline *SecretsBaseline) {
+	s.baseline = baseline
+}
+
+// ScanContent scans all lines of a file
+func (s *SecretScanner) ScanContent(path, content string) []*SecretFinding {
+	var findings []*SecretFinding
+	for nr, line := range strings.Split(content, "\n") {
+		findings = append(findings, s.ScanLine(path, nr+1, line)...)
+	}
+	return findings
+}
+
+// ScanLine scans a single line of a file
+func (s *SecretScanner) ScanLine(path string, nr int, line string) []*SecretFinding {
+	if s.marker != "" && strings.Contains(line, s.marker) {
+		return nil
+	}
+	var matches []string
+	for _, r := range s.blocked {
+		matches = append(matches, r.FindAllString(line, -1)...)
+	}
+	if s.entropy != nil {
+		matches = append(matches, s.entropy.Find(line)...)
+	}
+
+	// a secret matching several patterns or the entropy detector is reported only once
+	var findings []*SecretFinding
+	reported := map[string]bool{}
+	for _, match := range matches {
+		if reported[match] || s.isAllowed(match) {
+			continue
+		}
+		reported[match] = true
+		finding := &SecretFinding{Path: path, Line: nr, Secret: match}
+		if s.baseline != nil && s.baseline.Contains(finding) {
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func (s *SecretScanner) isAllowed(blocked string) bool {
+	for _, r := range s.allowed {
+		if r.MatchString(blocked) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecretScanner creates a SecretScanner from the `File.BlockSecrets` action options
+// Relative paths to preset files are relative to the repository root.
+func NewSecretScanner(options *configuration.Options, root string) (*SecretScanner, error) {
+	regs := options.AsSliceOfStrings("blocked")
+	for _, name := range options.AsSliceOfStrings("presets") {
+		preset, err := GetPreset(name)
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, preset...)
+	}
+	for _, file := range options.AsSliceOfStrings("preset-files") {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(root, file)
+		}
+		preset, err := LoadPresetFile(file)
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, preset...)
+	}
+	blocked, err := compileAll(regs)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := compileAll(options.AsSliceOfStrings("allowed"))
+	if err != nil {
+		return nil, err
+	}
+	scanner := &SecretScanner{
+		blocked: blocked,
+		allowed: allowed,
+		marker:  options.AsString("allow-marker", DefaultAllowMarker),
+	}
+	if options.AsBool("entropy", false) {
+		scanner.entropy = NewEntropyDetector(
+			options.AsFloat("entropy-threshold", DefaultEntropyThreshold),
+			options.AsInt("entropy-min-length", DefaultEntropyMinLength),
+		)
+	}
+	return scanner, nil
+}
+
+func compileAll(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex '%s': %s", pattern, err.Error())
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled, nil
+}
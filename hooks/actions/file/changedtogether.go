@@ -0,0 +1,119 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/hooks/util"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// ChangedTogether makes sure files that belong together are changed together.
+// If any staged or pushed file matches one of the 'if-changed' patterns, every 'require' pattern
+// has to match at least one staged or pushed file as well.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::File.ChangedTogether",
+//	  "options": {
+//	    "rules": [
+//	      {"if-changed": ["go.mod"], "require": ["go.sum"], "hint": "run 'go mod tidy'"},
+//	      {"if-changed": ["db/migrations/*.sql"], "require": ["db/schema.sql"], "hint": "dump the schema"}
+//	    ]
+//	  }
+//	}
+type ChangedTogether struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *ChangedTogether) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *ChangedTogether) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking files changed together", true, io.VERBOSE)
+
+	files, err := a.files()
+	if err != nil {
+		return err
+	}
+	return a.check(action.Options(), files)
+}
+
+// files returns the staged files in 'pre-commit' hooks and the files changed by all pushed refs in 'pre-push' hooks
+func (a *ChangedTogether) files() ([]string, error) {
+	if a.hookBundle.AppIO.Argument(info.ArgCommand, "") == info.PrePush {
+		return input.PushedFiles(a.hookBundle.AppIO, a.hookBundle.Repo)
+	}
+	return a.hookBundle.Repo.StagedFiles()
+}
+
+func (a *ChangedTogether) check(options *configuration.Options, files []string) error {
+	rules, ok := options.All()["rules"].([]interface{})
+	if !ok {
+		return errors.New("option 'rules' is missing")
+	}
+
+	var problems []string
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			return errors.New("option 'rules' has to be a list of objects")
+		}
+		ruleOptions := configuration.NewOptions(ruleMap)
+
+		changed := matchingFiles(files, ruleOptions.AsSliceOfStrings("if-changed"))
+		if len(changed) == 0 {
+			continue
+		}
+		var missing []string
+		for _, pattern := range ruleOptions.AsSliceOfStrings("require") {
+			if len(util.FilterByPattern(files, pattern)) == 0 {
+				missing = append(missing, pattern)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		problem := fmt.Sprintf(
+			"<comment>%s</comment> changed without <comment>%s</comment>",
+			strings.Join(changed, ", "),
+			strings.Join(missing, ", "),
+		)
+		if hint := ruleOptions.AsString("hint", ""); hint != "" {
+			problem += "\n    " + hint
+		}
+		problems = append(problems, problem)
+	}
+	if len(problems) > 0 {
+		return errors.New("files have to be changed together:\n  - " + strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// matchingFiles returns all files matching any of the given patterns
+func matchingFiles(files []string, patterns []string) []string {
+	var matching []string
+	for _, file := range files {
+		for _, pattern := range patterns {
+			if util.MatchesPattern(file, pattern) {
+				matching = append(matching, file)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+func NewChangedTogether(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := ChangedTogether{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PreCommit, info.PrePush}),
+	}
+	return &a
+}
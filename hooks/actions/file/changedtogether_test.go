@@ -0,0 +1,82 @@
+package file
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestChangedTogether(t *testing.T) {
+	a := NewChangedTogether(test.CreateFakeIO(), test.CreateFakeConfig(), test.CreateFakeRepo()).(*ChangedTogether)
+	options := configuration.NewOptions(map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{
+				"if-changed": []interface{}{"go.mod"},
+				"require":    []interface{}{"go.sum"},
+				"hint":       "run 'go mod tidy'",
+			},
+			map[string]interface{}{
+				"if-changed": []interface{}{"db/migrations/*.sql"},
+				"require":    []interface{}{"db/schema.sql"},
+			},
+		},
+	})
+
+	if err := a.check(options, []string{"go.mod", "go.sum", "main.go"}); err != nil {
+		t.Errorf("Files changed together should pass, got: %s", err.Error())
+	}
+	err := a.check(options, []string{"go.mod", "db/migrations/001.sql"})
+	if err == nil {
+		t.Fatalf("Files not changed together should fail")
+	}
+	if !strings.Contains(err.Error(), "<comment>go.mod</comment> changed without <comment>go.sum</comment>\n    run 'go mod tidy'") ||
+		!strings.Contains(err.Error(), "<comment>db/migrations/001.sql</comment> changed without <comment>db/schema.sql</comment>") {
+		t.Errorf("Wrong problems reported: %s", err.Error())
+	}
+}
+
+func TestChangedTogetherPushOfNewBranch(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/feature 12345 refs/heads/feature 0000000000000000000000000000000000000000\n"})
+	repo := test.CreateFakeRepo()
+	repo.SetLog([]*types.Commit{{Hash: "abc1234"}})
+	repo.SetDiff(&types.Diff{Files: []*types.FileDiff{{NewPath: "go.mod"}}})
+	a := NewChangedTogether(inOut, test.CreateFakeConfig(), repo).(*ChangedTogether)
+
+	err := checkChangedTogether(a)
+	if err == nil || !strings.Contains(err.Error(), "<comment>go.mod</comment> changed without <comment>go.sum</comment>") {
+		t.Errorf("Files of the new branch should be checked, got: %v", err)
+	}
+}
+
+func TestChangedTogetherPushOfMultipleRefs(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876\n" +
+		"refs/heads/feature 67890 refs/heads/feature 0000000000000000000000000000000000000000\n"})
+	repo := test.CreateFakeRepo()
+	repo.SetFiles([]string{"main.go"})
+	repo.SetLog([]*types.Commit{{Hash: "abc1234"}})
+	repo.SetDiff(&types.Diff{Files: []*types.FileDiff{{NewPath: "go.mod"}}})
+	a := NewChangedTogether(inOut, test.CreateFakeConfig(), repo).(*ChangedTogether)
+
+	err := checkChangedTogether(a)
+	if err == nil || !strings.Contains(err.Error(), "<comment>go.mod</comment> changed without <comment>go.sum</comment>") {
+		t.Errorf("Files of all pushed refs should be checked, got: %v", err)
+	}
+}
+
+func checkChangedTogether(a *ChangedTogether) error {
+	files, err := a.files()
+	if err != nil {
+		return err
+	}
+	return a.check(configuration.NewOptions(map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"if-changed": []interface{}{"go.mod"}, "require": []interface{}{"go.sum"}},
+		},
+	}), files)
+}
@@ -41,7 +41,7 @@ func (a *DoesNotContainRegex) Run(action *configuration.Action) error {
 		return err
 	}
 	for _, file := range files {
-		content, _ := io.ReadFile(file)
+		content, _ := a.hookBundle.Repo.FileContent(file, "")
 		matched, readErr := regexp.MatchString(reg, string(content))
 		if readErr != nil {
 			return readErr
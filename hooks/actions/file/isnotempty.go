@@ -5,6 +5,7 @@ import (
 	"github.com/captainhook-go/captainhook/configuration"
 	"github.com/captainhook-go/captainhook/git"
 	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
 	"github.com/captainhook-go/captainhook/io"
 )
 
@@ -29,13 +30,15 @@ func (a *IsNotEmpty) IsApplicableFor(hook string) bool {
 func (a *IsNotEmpty) Run(action *configuration.Action) error {
 	a.hookBundle.AppIO.Write("checking if file is empty", true, io.VERBOSE)
 
-	for _, file := range action.Options().AsSliceOfStrings("files") {
-		content, err := io.ReadFile(file)
-		if err != nil {
-			return fmt.Errorf("file not found: %s", file)
-		}
-		if len(content) < 1 {
-			return fmt.Errorf("file '%s' can't be empty", file)
+	for _, revision := range input.StagedOrChangedRevisions(a.hookBundle.AppIO) {
+		for _, file := range action.Options().AsSliceOfStrings("files") {
+			size, err := a.hookBundle.Repo.FileSize(file, revision)
+			if err != nil {
+				return fmt.Errorf("file not found: %s", file)
+			}
+			if size < 1 {
+				return fmt.Errorf("file '%s' can't be empty", file)
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,80 @@
+package file
+
+import (
+	"errors"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/util"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// IsValidSyntax prevents you from committing JSON, YAML, XML or TOML files that can not be parsed.
+// The staged content is checked, not the files in your working tree. Files are detected by extension.
+// Use 'exclude' to skip files like JSON files with comments.
+// Only applicable for 'pre-commit' hooks.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::File.IsValidSyntax",
+//	  "options": {
+//	    "exclude": [".vscode/", "tsconfig.json"]
+//	  }
+//	}
+type IsValidSyntax struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *IsValidSyntax) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *IsValidSyntax) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking file syntax", true, io.VERBOSE)
+
+	files, err := a.hookBundle.Repo.StagedFiles()
+	if err != nil {
+		return err
+	}
+	return a.check(files, action.Options().AsSliceOfStrings("exclude"))
+}
+
+func (a *IsValidSyntax) check(files, exclude []string) error {
+	var problems []string
+	for _, file := range files {
+		if !HasSyntaxValidator(file) || isExcluded(file, exclude) {
+			continue
+		}
+		a.hookBundle.AppIO.Write("  "+file, true, io.DEBUG)
+		content, err := a.hookBundle.Repo.FileContent(file, "")
+		if err != nil {
+			return err
+		}
+		if syntaxErr := ValidateSyntax(file, content); syntaxErr != nil {
+			problems = append(problems, syntaxErr.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("invalid syntax:\n  - " + strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+func isExcluded(file string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if util.MatchesPattern(file, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewIsValidSyntax(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := IsValidSyntax{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PreCommit}),
+	}
+	return &a
+}
@@ -0,0 +1,156 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/util"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// LicenseHeader makes sure all staged files matching the 'files' patterns start with a license header.
+// The header is configured as 'header' text or 'header-file' and can use the variables {year} and {holder}.
+// Existing headers are accepted with any year. The comment style is detected by file extension or can
+// be set with 'comment-style' to '//', '#' or '/* */'.
+// With 'fix' enabled missing headers are inserted and the files are staged again. Files with unstaged
+// changes are not fixed, so no unstaged changes get committed by accident.
+// Only applicable for 'pre-commit' hooks.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::File.LicenseHeader",
+//	  "options": {
+//	    "files": ["*.go", "scripts/*.sh"],
+//	    "header": "Copyright {year} {holder}\nSPDX-License-Identifier: MIT",
+//	    "holder": "ACME Inc.",
+//	    "fix": true
+//	  }
+//	}
+type LicenseHeaderCheck struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *LicenseHeaderCheck) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *LicenseHeaderCheck) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking license headers", true, io.VERBOSE)
+
+	header, err := a.header(action.Options())
+	if err != nil {
+		return err
+	}
+	staged, err := a.hookBundle.Repo.StagedFiles()
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, pattern := range action.Options().AsSliceOfStrings("files") {
+		for _, file := range util.FilterByPattern(staged, pattern) {
+			if !slices.Contains(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+	return a.check(action.Options(), header, files)
+}
+
+func (a *LicenseHeaderCheck) check(options *configuration.Options, header *LicenseHeader, files []string) error {
+	fix := options.AsBool("fix", false)
+	var missing, fixed []string
+	for _, file := range files {
+		style, err := a.commentStyle(options, file)
+		if err != nil {
+			return err
+		}
+		content, err := a.hookBundle.Repo.FileContent(file, "")
+		if err != nil {
+			return err
+		}
+		if header.HasHeader(content, style) {
+			continue
+		}
+		if fix && a.fix(file, content, header, style) {
+			fixed = append(fixed, file)
+			continue
+		}
+		missing = append(missing, file)
+	}
+
+	if len(fixed) > 0 {
+		if err := a.hookBundle.Repo.AddFiles(fixed); err != nil {
+			return err
+		}
+		a.hookBundle.AppIO.Write("added license header to:\n  - "+strings.Join(fixed, "\n  - "), true, io.NORMAL)
+	}
+	if len(missing) > 0 {
+		msg := "license header missing in:\n  - " + strings.Join(missing, "\n  - ")
+		if fix {
+			msg += "\nfiles with unstaged changes are not fixed automatically"
+		}
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// fix writes the header to the working tree file
+// Files with unstaged changes are skipped, staging them again would stage those changes as well.
+func (a *LicenseHeaderCheck) fix(file string, staged []byte, header *LicenseHeader, style *CommentStyle) bool {
+	path := filepath.Join(a.hookBundle.Repo.Path(), file)
+	current, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(current, staged) {
+		return false
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.WriteFile(path, header.AddHeader(staged, style), stat.Mode().Perm()) == nil
+}
+
+func (a *LicenseHeaderCheck) header(options *configuration.Options) (*LicenseHeader, error) {
+	template := options.AsString("header", "")
+	if file := options.AsString("header-file", ""); file != "" {
+		content, err := os.ReadFile(filepath.Join(a.hookBundle.Repo.Path(), file))
+		if err != nil {
+			return nil, fmt.Errorf("could not read header file '%s'", file)
+		}
+		template = string(content)
+	}
+	if strings.TrimSpace(template) == "" {
+		return nil, errors.New("option 'header' or 'header-file' is missing")
+	}
+	return NewLicenseHeader(template, options.AsString("holder", ""), options.AsInt("year", 0)), nil
+}
+
+func (a *LicenseHeaderCheck) commentStyle(options *configuration.Options, file string) (*CommentStyle, error) {
+	if configured := options.AsString("comment-style", ""); configured != "" {
+		style, ok := NewCommentStyle(configured)
+		if !ok {
+			return nil, fmt.Errorf("invalid comment style '%s'", configured)
+		}
+		return style, nil
+	}
+	style, ok := CommentStyleFor(file)
+	if !ok {
+		return nil, fmt.Errorf("unknown comment style for '%s', use option 'comment-style'", file)
+	}
+	return style, nil
+}
+
+func NewLicenseHeaderCheck(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := LicenseHeaderCheck{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PreCommit}),
+	}
+	return &a
+}
@@ -0,0 +1,154 @@
+package file
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const yearPattern = `[0-9]{4}(?:\s*-\s*[0-9]{4})?`
+
+var (
+	// commentStyles maps file extensions to the comment style used for the license header
+	commentStyles = map[string]string{
+		".c": "/* */", ".cpp": "//", ".cs": "//", ".css": "/* */", ".dart": "//", ".go": "//",
+		".h": "/* */", ".hpp": "//", ".java": "//", ".js": "//", ".jsx": "//", ".kt": "//",
+		".php": "//", ".rs": "//", ".scala": "//", ".scss": "//", ".swift": "//", ".ts": "//",
+		".tsx": "//", ".bash": "#", ".pl": "#", ".ps1": "#", ".py": "#", ".r": "#", ".rb": "#",
+		".sh": "#", ".tf": "#", ".toml": "#", ".yaml": "#", ".yml": "#", ".zsh": "#",
+	}
+	encodingLine = regexp.MustCompile(`^#.*coding[:=]`)
+)
+
+// CommentStyle describes how a license header is turned into a comment
+type CommentStyle struct {
+	Start string
+	Line  string
+	End   string
+}
+
+// NewCommentStyle creates a CommentStyle for '//', '#' or '/* */'
+func NewCommentStyle(style string) (*CommentStyle, bool) {
+	switch style {
+	case "//", "#":
+		return &CommentStyle{Line: style}, true
+	case "/* */":
+		return &CommentStyle{Start: "/*", Line: " *", End: " */"}, true
+	}
+	return nil, false
+}
+
+// CommentStyleFor returns the CommentStyle for a file based on its extension
+func CommentStyleFor(file string) (*CommentStyle, bool) {
+	style, ok := commentStyles[strings.ToLower(path.Ext(file))]
+	if !ok {
+		return nil, false
+	}
+	return NewCommentStyle(style)
+}
+
+// LicenseHeader renders and detects license headers
+// The header template can use the variables {year} and {holder}.
+type LicenseHeader struct {
+	template []string
+	holder   string
+	year     string
+}
+
+// Render returns the commented header lines
+func (h *LicenseHeader) Render(style *CommentStyle) []string {
+	var lines []string
+	for _, line := range h.comment(style) {
+		line = strings.ReplaceAll(line, "{year}", h.year)
+		// trim after replacing the variables, an empty holder must not leave a trailing space
+		lines = append(lines, strings.TrimRight(strings.ReplaceAll(line, "{holder}", h.holder), " "))
+	}
+	return lines
+}
+
+// HasHeader checks if a files content starts with the license header
+// Shebangs, encoding declarations, build tags and blank lines in front of the header are ignored.
+// The header is accepted with any year or year range.
+func (h *LicenseHeader) HasHeader(content []byte, style *CommentStyle) bool {
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	i := 0
+	for i < len(lines) && (isPreamble(lines[i], i) || isBuildTag(lines[i]) || strings.TrimSpace(lines[i]) == "") {
+		i++
+	}
+	patterns := h.patterns(style)
+	if len(lines)-i < len(patterns) {
+		return false
+	}
+	for n, pattern := range patterns {
+		if !pattern.MatchString(strings.TrimRight(lines[i+n], " \t")) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddHeader inserts the license header after a shebang, encoding declaration or '<?php' tag
+func (h *LicenseHeader) AddHeader(content []byte, style *CommentStyle) []byte {
+	lines := strings.Split(string(content), "\n")
+	i := 0
+	for i < len(lines) && isPreamble(lines[i], i) {
+		i++
+	}
+	header := append(h.Render(style), "")
+	if i > 0 {
+		header = append([]string{""}, header...)
+	}
+	lines = append(lines[:i], append(header, lines[i:]...)...)
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// comment turns the header template into a comment without replacing any variables
+func (h *LicenseHeader) comment(style *CommentStyle) []string {
+	var lines []string
+	if style.Start != "" {
+		lines = append(lines, style.Start)
+	}
+	for _, line := range h.template {
+		lines = append(lines, style.Line+" "+line)
+	}
+	if style.End != "" {
+		lines = append(lines, style.End)
+	}
+	return lines
+}
+
+// patterns returns a regex for every header line accepting any year
+func (h *LicenseHeader) patterns(style *CommentStyle) []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, line := range h.comment(style) {
+		quoted := regexp.QuoteMeta(strings.TrimRight(strings.ReplaceAll(line, "{holder}", h.holder), " "))
+		quoted = strings.ReplaceAll(quoted, regexp.QuoteMeta("{year}"), yearPattern)
+		patterns = append(patterns, regexp.MustCompile("^"+quoted+"$"))
+	}
+	return patterns
+}
+
+// isPreamble detects lines that have to stay at the top of a file
+func isPreamble(line string, index int) bool {
+	if index == 0 && (strings.HasPrefix(line, "#!") || strings.HasPrefix(line, "<?php") || strings.HasPrefix(line, "<?xml")) {
+		return true
+	}
+	return index < 2 && encodingLine.MatchString(line)
+}
+
+func isBuildTag(line string) bool {
+	return strings.HasPrefix(line, "//go:build") || strings.HasPrefix(line, "// +build")
+}
+
+func NewLicenseHeader(template, holder string, year int) *LicenseHeader {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	return &LicenseHeader{
+		template: strings.Split(strings.TrimRight(template, "\n"), "\n"),
+		holder:   holder,
+		year:     strconv.Itoa(year),
+	}
+}
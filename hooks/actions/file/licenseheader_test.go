@@ -0,0 +1,97 @@
+package file
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const licenseTemplate = "Copyright {year} {holder}\n\nSPDX-License-Identifier: MIT"
+
+func TestHasHeader(t *testing.T) {
+	header := NewLicenseHeader(licenseTemplate, "ACME Inc.", 2026)
+	slashes, _ := NewCommentStyle("//")
+	hashes, _ := NewCommentStyle("#")
+	block, _ := NewCommentStyle("/* */")
+
+	tests := []struct {
+		content string
+		style   *CommentStyle
+		has     bool
+	}{
+		{"// Copyright 2019 ACME Inc.\n//\n// SPDX-License-Identifier: MIT\n\npackage main\n", slashes, true},
+		{"//go:build linux\n\n// Copyright 2019-2024 ACME Inc.\n//\n// SPDX-License-Identifier: MIT\npackage main\n", slashes, true},
+		{"#!/bin/sh\n# Copyright 2026 ACME Inc.\n#\n# SPDX-License-Identifier: MIT\n", hashes, true},
+		{"/*\n * Copyright 2026 ACME Inc.\n *\n * SPDX-License-Identifier: MIT\n */\n", block, true},
+		{"// Copyright 2026 Someone Else\n//\n// SPDX-License-Identifier: MIT\n", slashes, false},
+		{"package main\n", slashes, false},
+	}
+	for i, test := range tests {
+		if header.HasHeader([]byte(test.content), test.style) != test.has {
+			t.Errorf("Test %d: header should be detected: %t", i, test.has)
+		}
+	}
+}
+
+func TestAddHeader(t *testing.T) {
+	header := NewLicenseHeader(licenseTemplate, "ACME Inc.", 2026)
+	hashes, _ := NewCommentStyle("#")
+
+	result := string(header.AddHeader([]byte("#!/bin/sh\necho foo\n"), hashes))
+	expected := "#!/bin/sh\n\n# Copyright 2026 ACME Inc.\n#\n# SPDX-License-Identifier: MIT\n\necho foo\n"
+	if result != expected {
+		t.Errorf("Header not added correctly, got:\n%s", result)
+	}
+	if !header.HasHeader([]byte(result), hashes) {
+		t.Errorf("Added header should be detected")
+	}
+}
+
+func TestLicenseHeaderFix(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "dirty.go"), []byte("package dirty\n\n// unstaged\n"), 0644)
+
+	repo := test.CreateFakeRepo().SetPath(dir).SetFileContents(map[string]string{
+		"main.go":  "package main\n",
+		"dirty.go": "package dirty\n",
+	})
+	a := NewLicenseHeaderCheck(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*LicenseHeaderCheck)
+	header := NewLicenseHeader(licenseTemplate, "ACME Inc.", 2026)
+
+	err := a.check(configuration.NewOptions(map[string]interface{}{"fix": true}), header, []string{"main.go", "dirty.go"})
+	if err == nil || !strings.Contains(err.Error(), "dirty.go") || strings.Contains(err.Error(), "main.go") {
+		t.Errorf("Only the file with unstaged changes should be reported, got: %v", err)
+	}
+	if len(repo.Added) != 1 || repo.Added[0] != "main.go" {
+		t.Errorf("Fixed file should be staged again, got: %v", repo.Added)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+	if !strings.HasPrefix(string(content), "// Copyright 2026 ACME Inc.\n") {
+		t.Errorf("Header should be written, got:\n%s", content)
+	}
+}
+
+func TestLicenseHeaderFixWithoutHolder(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644)
+
+	repo := test.CreateFakeRepo().SetPath(dir).SetFileContents(map[string]string{"main.go": "package main\n"})
+	a := NewLicenseHeaderCheck(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*LicenseHeaderCheck)
+	header := NewLicenseHeader(licenseTemplate, "", 2026)
+	slashes, _ := NewCommentStyle("//")
+
+	if err := a.check(configuration.NewOptions(map[string]interface{}{"fix": true}), header, []string{"main.go"}); err != nil {
+		t.Fatalf("Missing header should be fixed, got: %s", err.Error())
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+	if !strings.HasPrefix(string(content), "// Copyright 2026\n") {
+		t.Errorf("Header should be written without trailing space, got: %q", content)
+	}
+	if !header.HasHeader(content, slashes) {
+		t.Errorf("Fixed file should pass the check")
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/captainhook-go/captainhook/hooks"
 	"github.com/captainhook-go/captainhook/io"
 	"math"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -46,21 +45,14 @@ func (a *MaxSize) Run(action *configuration.Action) error {
 	}
 	for _, path := range files {
 		a.hookBundle.AppIO.Write(" - "+path, true, io.VERBOSE)
-		file, err := os.Open(path)
+		fileSize, err := a.hookBundle.Repo.FileSize(path, "")
 		if err != nil {
 			// ignore error because file is most likely deleted
 			continue
 		}
-		stats, err := file.Stat()
-		if err != nil {
-			return fmt.Errorf("failed to read file at: %s", path)
-		}
-
-		if sizeInBytes < stats.Size() {
-			_ = file.Close()
+		if sizeInBytes < fileSize {
 			return fmt.Errorf("file '%s' is bigger than the limit of %s", path, size)
 		}
-		_ = file.Close()
 	}
 	return nil
 }
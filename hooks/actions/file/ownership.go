@@ -0,0 +1,154 @@
+package file
+
+import (
+	"errors"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/codeowners"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/hooks/util"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// Ownership lists the CODEOWNERS owners of all staged or pushed files.
+// If you configure your 'team' it warns you about files owned by other teams. In 'block' mode
+// those changes are blocked. With 'require-review' a 'Reviewed-by' trailer naming one of the owners
+// allows the change in 'commit-msg' and 'pre-push' hooks. A trailer names an owner if it contains the
+// owners handle or email, e.g. 'Reviewed-by: Jane Doe <jane@acme.com>'.
+// The CODEOWNERS file is searched in '.github/', the repository root and 'docs/'.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::File.Ownership",
+//	  "options": {
+//	    "team": ["@acme/backend", "jane@acme.com"],
+//	    "mode": "warn",
+//	    "require-review": true
+//	  }
+//	}
+type Ownership struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *Ownership) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *Ownership) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking code ownership", true, io.VERBOSE)
+
+	err := a.checkOwnership(action.Options())
+	if err != nil && action.Options().AsString("mode", "warn") == "warn" {
+		a.hookBundle.AppIO.Write("<comment>"+err.Error()+"</comment>", true, io.NORMAL)
+		return nil
+	}
+	return err
+}
+
+// checkOwnership loads the owners and the changed files and checks them
+func (a *Ownership) checkOwnership(options *configuration.Options) error {
+	owners, err := codeowners.Load(a.hookBundle.Repo)
+	if err != nil {
+		return err
+	}
+	files, err := a.files()
+	if err != nil {
+		return err
+	}
+	var reviewers []string
+	if options.AsBool("require-review", false) {
+		if reviewers, err = a.reviewers(); err != nil {
+			return err
+		}
+	}
+	return a.check(options, owners, files, reviewers)
+}
+
+func (a *Ownership) check(options *configuration.Options, owners *codeowners.CodeOwners, files, reviewers []string) error {
+	for _, owner := range owners.AllOwners(files) {
+		a.hookBundle.AppIO.Write("<info>"+owner+"</info> "+strings.Join(owners.OwnersOf(files)[owner], ", "), true, io.NORMAL)
+	}
+
+	team := options.AsSliceOfStrings("team")
+	if len(team) == 0 {
+		return nil
+	}
+	var foreign []string
+	for _, file := range files {
+		fileOwners := owners.Owners(file)
+		if len(fileOwners) == 0 || util.ContainsAnyString(fileOwners, team) {
+			continue
+		}
+		if options.AsBool("require-review", false) && isReviewed(fileOwners, reviewers) {
+			continue
+		}
+		foreign = append(foreign, " - "+file+" <comment>"+strings.Join(fileOwners, " ")+"</comment>")
+	}
+	if len(foreign) == 0 {
+		return nil
+	}
+	msg := "files owned by other teams:\n" + strings.Join(foreign, "\n")
+	if options.AsBool("require-review", false) {
+		msg += "\nadd a 'Reviewed-by' trailer naming one of the owners"
+	}
+	return errors.New(msg)
+}
+
+// files returns the staged files for commit hooks and the files of all pushed refs otherwise
+func (a *Ownership) files() ([]string, error) {
+	switch a.hookBundle.AppIO.Argument(info.ArgCommand, "") {
+	case info.PreCommit, info.CommitMsg:
+		return a.hookBundle.Repo.StagedFiles()
+	}
+	return input.PushedFiles(a.hookBundle.AppIO, a.hookBundle.Repo)
+}
+
+// reviewers returns the values of all 'Reviewed-by' trailers
+// In 'commit-msg' hooks the trailers of the commit message are used, in 'pre-push' hooks the ones of
+// all pushed commits. Other hooks have no message to read trailers from.
+func (a *Ownership) reviewers() ([]string, error) {
+	switch a.hookBundle.AppIO.Argument(info.ArgCommand, "") {
+	case info.CommitMsg:
+		msg, err := a.hookBundle.Repo.CommitMessage(a.hookBundle.AppIO.Argument(info.ArgCommitMsgFile, ""))
+		if err != nil {
+			return nil, err
+		}
+		return msg.TrailerValues("Reviewed-by"), nil
+	case info.PrePush:
+		var reviewers []string
+		for _, commit := range input.PushedCommits(a.hookBundle.AppIO, a.hookBundle.Repo) {
+			msg := types.NewCommitMessage(commit.Subject+"\n\n"+strings.Trim(commit.Body, "\n"), types.NoCommentChar)
+			reviewers = append(reviewers, msg.TrailerValues("Reviewed-by")...)
+		}
+		return reviewers, nil
+	}
+	return nil, nil
+}
+
+// isReviewed checks if any reviewer names one of the owners
+// Handles and emails have to match completely, so '@bob' is not reviewed by '@bobby'.
+func isReviewed(owners, reviewers []string) bool {
+	for _, reviewer := range reviewers {
+		for _, name := range strings.Fields(reviewer) {
+			name = strings.Trim(name, "<>,;()")
+			for _, owner := range owners {
+				if strings.EqualFold(name, owner) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func NewOwnership(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := Ownership{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PreCommit, info.CommitMsg, info.PrePush}),
+	}
+	return &a
+}
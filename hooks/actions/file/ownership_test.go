@@ -0,0 +1,42 @@
+package file
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/hooks/codeowners"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestOwnershipBlocksForeignFiles(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	a := NewOwnership(inOut, test.CreateFakeConfig(), test.CreateFakeRepo()).(*Ownership)
+	owners := codeowners.Parse("* @acme/backend\n/web/ @acme/frontend\n")
+	files := []string{"main.go", "web/app.js"}
+	options := map[string]interface{}{"team": []interface{}{"@acme/backend"}, "require-review": true}
+
+	err := a.check(configuration.NewOptions(options), owners, files, nil)
+	if err == nil {
+		t.Fatalf("Files owned by other teams should be reported")
+	}
+	if !strings.Contains(err.Error(), "web/app.js") || strings.Contains(err.Error(), "main.go") {
+		t.Errorf("Only foreign files should be reported, got: %s", err.Error())
+	}
+	if len(inOut.Out) != 2 || inOut.Out[0] != "<info>@acme/backend</info> main.go" {
+		t.Errorf("Owners should be listed, got: %v", inOut.Out)
+	}
+
+	reviewers := []string{"Jane Doe <jane@acme.com> @acme/frontend"}
+	if err := a.check(configuration.NewOptions(options), owners, files, reviewers); err != nil {
+		t.Errorf("Reviewed files should be allowed, got: %s", err.Error())
+	}
+}
+
+func TestIsReviewedComparesWholeHandles(t *testing.T) {
+	if isReviewed([]string{"@bob"}, []string{"Bobby <@bobby>"}) {
+		t.Errorf("'@bobby' should not review files owned by '@bob'")
+	}
+	if !isReviewed([]string{"@bob", "bob@acme.com"}, []string{"Bob <bob@acme.com>"}) {
+		t.Errorf("Reviewer email should match the owner email")
+	}
+}
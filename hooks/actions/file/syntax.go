@@ -0,0 +1,139 @@
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// syntaxValidators maps file extensions to the function validating the files content
+	syntaxValidators = map[string]func(content []byte) *SyntaxError{
+		".json": validateJSON,
+		".toml": validateTOML,
+		".xml":  validateXML,
+		".yaml": validateYAML,
+		".yml":  validateYAML,
+	}
+	yamlLine = regexp.MustCompile(`line ([0-9]+)(: )?`)
+)
+
+// SyntaxError describes a parse error with its position
+// If the parser does not report a line or column they are 0.
+type SyntaxError struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	position := e.File
+	if e.Line > 0 {
+		position += ":" + strconv.Itoa(e.Line)
+	}
+	if e.Line > 0 && e.Column > 0 {
+		position += ":" + strconv.Itoa(e.Column)
+	}
+	return position + ": " + e.Msg
+}
+
+// HasSyntaxValidator tells you if the syntax of a file can be validated
+func HasSyntaxValidator(file string) bool {
+	_, ok := syntaxValidators[strings.ToLower(path.Ext(file))]
+	return ok
+}
+
+// ValidateSyntax parses the content of a file based on its extension
+// Files without a validator are always valid.
+func ValidateSyntax(file string, content []byte) *SyntaxError {
+	validator, ok := syntaxValidators[strings.ToLower(path.Ext(file))]
+	if !ok {
+		return nil
+	}
+	err := validator(content)
+	if err != nil {
+		err.File = file
+	}
+	return err
+}
+
+func validateJSON(content []byte) *SyntaxError {
+	var data interface{}
+	err := json.Unmarshal(content, &data)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, column := position(content, int(syntaxErr.Offset)-1)
+		return &SyntaxError{Line: line, Column: column, Msg: syntaxErr.Error()}
+	}
+	line, column := position(content, len(content))
+	return &SyntaxError{Line: line, Column: column, Msg: err.Error()}
+}
+
+func validateYAML(content []byte) *SyntaxError {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var data interface{}
+		err := decoder.Decode(&data)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			msg := strings.TrimPrefix(err.Error(), "yaml: ")
+			line := 0
+			if match := yamlLine.FindStringSubmatch(msg); match != nil {
+				line, _ = strconv.Atoi(match[1])
+				if strings.HasPrefix(msg, match[0]) && match[2] != "" {
+					msg = strings.TrimPrefix(msg, match[0])
+				}
+			}
+			return &SyntaxError{Line: line, Msg: msg}
+		}
+	}
+}
+
+func validateXML(content []byte) *SyntaxError {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			// the line of the syntax error can differ from the decoders position, so both are taken from the decoder
+			line, column := decoder.InputPos()
+			var syntaxErr *xml.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return &SyntaxError{Line: line, Column: column, Msg: syntaxErr.Msg}
+			}
+			return &SyntaxError{Line: line, Column: column, Msg: err.Error()}
+		}
+	}
+}
+
+// position converts a byte offset to a line and column
+func position(content []byte, offset int) (int, int) {
+	offset = max(0, min(offset, len(content)))
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+	return line, column
+}
+
+// syntaxErrorAt creates a SyntaxError for a byte offset
+func syntaxErrorAt(content []byte, offset int, format string, args ...interface{}) *SyntaxError {
+	line, column := position(content, offset)
+	return &SyntaxError{Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,137 @@
+package file
+
+import (
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestValidateSyntax(t *testing.T) {
+	tests := []struct {
+		file    string
+		content string
+		line    int
+		column  int
+	}{
+		{"captainhook.json", `{"config": {}, "hooks": {}}`, 0, 0},
+		{"captainhook.json", "{\n  \"config\": {},\n  \"hooks\": {,}\n}", 3, 13},
+		{"ci.yml", "jobs:\n  test:\n    runs-on: ubuntu\n", 0, 0},
+		{"ci.yaml", "jobs:\n  test:\n\t  runs-on: ubuntu\n", 3, 0},
+		{"multi.yml", "a: 1\n---\nb: 2\n", 0, 0},
+		{"pom.xml", "<project><name>foo</name></project>", 0, 0},
+		{"pom.xml", "<project>\n  <name>foo</nam>\n</project>", 2, 18},
+		{"pom.xml", "<project>\n  <name>foo</name>\n", 3, 1},
+		{"Cargo.toml", "\xEF\xBB\xBF[package]\nname = \"hook\"\n", 0, 0},
+		{"Cargo.toml", "[package]\nname = \"hook\"\nname = \"other\"\n", 3, 1},
+		{"README.md", "{ not json", 0, 0},
+	}
+	for _, test := range tests {
+		err := ValidateSyntax(test.file, []byte(test.content))
+		if test.line == 0 {
+			if err != nil {
+				t.Errorf("%s should be valid, got: %s", test.file, err.Error())
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s should be invalid", test.file)
+			continue
+		}
+		if err.File != test.file || err.Line != test.line || err.Column != test.column || strings.Contains(err.Msg, "line") {
+			t.Errorf("Wrong position for %s, got: %s", test.file, err.Error())
+		}
+	}
+}
+
+func TestValidateTOML(t *testing.T) {
+	valid := `# comment
+title = "TOML \"Example\" \u00e9"
+path = 'C:\Users'
+"quoted key" = 1_000
+site."google.com" = true
+
+[owner]
+dob = 1979-05-27T07:32:00-08:00
+lunch = 1979-05-27 12:00:00
+time = 07:32:00.999
+hex = 0xDEAD_BEEF
+float = -3.14e+10
+special = [inf, -nan]
+
+[database]
+ports = [
+  8000, # first
+  8001,
+]
+data = [ ["delta", "phi"], [3.14] ]
+point = { x = 1, y = { z = "a" } }
+text = """
+Roses are red \
+    Violets are blue"""
+raw = '''
+no \escape'''
+
+[[products]]
+name = "Hammer"
+[products.dimensions]
+length = 1
+
+[[products]]
+name = "Nail"
+[products.dimensions]
+length = 2
+`
+	if err := validateTOML([]byte(valid)); err != nil {
+		t.Errorf("TOML should be valid, got: %s", err.Error())
+	}
+
+	tests := []struct {
+		content string
+		line    int
+		column  int
+	}{
+		{"key = \"unterminated\n", 1, 20},
+		{"[table\nkey = 1\n", 1, 7},
+		{"a = 1\nb = 2 c = 3\n", 2, 7},
+		{"a = tru\n", 1, 5},
+		{"a = [1, 2\n", 2, 1},
+		{"a = \"\\x\"\n", 1, 7},
+		{"a = { b = 1, }\n", 1, 14},
+		{"= 1\n", 1, 1},
+		{"a = 1\nb = 2\na = 3\n", 3, 1},
+		{"[a]\nb = 1\n\n[a]\nc = 1\n", 4, 2},
+		{"a.b = 1\n[a]\n", 2, 2},
+		{"a = { b = 1, b = 2 }\n", 1, 14},
+		{"a = { b = 1 }\n[a.c]\n", 2, 2},
+		{"[[a]]\nb = 1\nb = 2\n", 3, 1},
+		{"\xEF\xBB\xBFa = \"x\n", 1, 7},
+	}
+	for _, test := range tests {
+		err := validateTOML([]byte(test.content))
+		if err == nil {
+			t.Errorf("TOML should be invalid: %q", test.content)
+			continue
+		}
+		if err.Line != test.line || err.Column != test.column {
+			t.Errorf("Wrong position for %q, got: %d:%d %s", test.content, err.Line, err.Column, err.Msg)
+		}
+	}
+}
+
+func TestIsValidSyntaxChecksStagedContent(t *testing.T) {
+	repo := test.CreateFakeRepo().SetFileContents(map[string]string{
+		"captainhook.json":         "{\"config\": {}",
+		".vscode/settings.json":    "{ // comment\n}",
+		".github/workflows/ci.yml": "on: push\n",
+		"main.go":                  "package main",
+	})
+	a := NewIsValidSyntax(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*IsValidSyntax)
+
+	err := a.check([]string{"captainhook.json", ".vscode/settings.json", ".github/workflows/ci.yml", "main.go"}, []string{".vscode/"})
+	if err == nil {
+		t.Fatalf("Broken JSON should be blocked")
+	}
+	if !strings.Contains(err.Error(), "captainhook.json:1:13") || strings.Contains(err.Error(), "settings.json") {
+		t.Errorf("Only the broken config should be reported, got: %s", err.Error())
+	}
+}
@@ -0,0 +1,468 @@
+package file
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tomlKind tells how a key path got defined
+type tomlKind int
+
+const (
+	tomlValue tomlKind = iota + 1
+	tomlTable
+	tomlArrayTable
+	// tables created implicitly by a table header like [a.b] can be defined later
+	tomlImplicitTable
+	// tables created by dotted keys like a.b = 1 can not be defined by a header later
+	tomlDottedTable
+)
+
+var (
+	tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+`)
+	tomlScalar  = regexp.MustCompile(`^(?:` +
+		// offset date-time, local date-time and local date
+		`[0-9]{4}-[0-9]{2}-[0-9]{2}(?:[Tt ][0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?(?:[Zz]|[+-][0-9]{2}:[0-9]{2})?)?` +
+		// local time
+		`|[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?` +
+		// hex, octal and binary integers
+		`|0x[0-9A-Fa-f](?:_?[0-9A-Fa-f])*|0o[0-7](?:_?[0-7])*|0b[01](?:_?[01])*` +
+		// special floats
+		`|[+-]?(?:inf|nan)` +
+		// decimal integers and floats
+		`|[+-]?(?:0|[1-9](?:_?[0-9])*)(?:\.[0-9](?:_?[0-9])*)?(?:[eE][+-]?[0-9](?:_?[0-9])*)?` +
+		`|true|false)`)
+)
+
+// tomlChecker validates the syntax of TOML documents
+// Besides the grammar it detects keys and tables that are defined more than once.
+type tomlChecker struct {
+	content []byte
+	pos     int
+	// defined keeps track of all defined key paths
+	defined map[string]tomlKind
+	// arrayTables counts the entries of each array of tables
+	arrayTables map[string]int
+	// table is the resolved path of the current table
+	table string
+}
+
+func validateTOML(content []byte) *SyntaxError {
+	c := &tomlChecker{
+		content:     bytes.TrimPrefix(content, []byte("\xEF\xBB\xBF")),
+		defined:     map[string]tomlKind{},
+		arrayTables: map[string]int{},
+	}
+	return c.document()
+}
+
+func (c *tomlChecker) document() *SyntaxError {
+	for {
+		c.skipWhitespace()
+		if c.eof() {
+			return nil
+		}
+		switch c.peek() {
+		case '#':
+			c.skipComment()
+			continue
+		case '\r', '\n':
+			c.pos++
+			continue
+		case '[':
+			if err := c.tableHeader(); err != nil {
+				return err
+			}
+		default:
+			if err := c.keyValue(c.table); err != nil {
+				return err
+			}
+		}
+		if err := c.lineEnd(); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *tomlChecker) tableHeader() *SyntaxError {
+	closing := "]"
+	c.pos++
+	if c.peek() == '[' {
+		closing = "]]"
+		c.pos++
+	}
+	c.skipWhitespace()
+	start := c.pos
+	parts, err := c.key()
+	if err != nil {
+		return err
+	}
+	c.skipWhitespace()
+	if !c.consume(closing) {
+		return c.errorf("expected '%s' to close the table header", closing)
+	}
+
+	path := ""
+	for _, part := range parts[:len(parts)-1] {
+		path = c.resolve(path, part)
+		switch c.defined[path] {
+		case tomlValue:
+			return syntaxErrorAt(c.content, start, "key '%s' is already defined", joinTOMLKey(parts))
+		case 0:
+			c.defined[path] = tomlImplicitTable
+		}
+	}
+	path = joinTOMLPath(path, parts[len(parts)-1])
+	kind := c.defined[path]
+	if closing == "]]" {
+		if kind != 0 && kind != tomlArrayTable {
+			return syntaxErrorAt(c.content, start, "key '%s' is already defined", joinTOMLKey(parts))
+		}
+		c.defined[path] = tomlArrayTable
+		c.arrayTables[path]++
+		c.table = c.resolve(path, "")
+		return nil
+	}
+	if kind != 0 && kind != tomlImplicitTable {
+		return syntaxErrorAt(c.content, start, "table '%s' is already defined", joinTOMLKey(parts))
+	}
+	c.defined[path] = tomlTable
+	c.table = path
+	return nil
+}
+
+func (c *tomlChecker) keyValue(table string) *SyntaxError {
+	start := c.pos
+	parts, err := c.key()
+	if err != nil {
+		return err
+	}
+	c.skipWhitespace()
+	if !c.consume("=") {
+		return c.errorf("expected '=' after key")
+	}
+	c.skipWhitespace()
+
+	path := table
+	for _, part := range parts[:len(parts)-1] {
+		path = joinTOMLPath(path, part)
+		switch c.defined[path] {
+		case 0, tomlImplicitTable:
+			c.defined[path] = tomlDottedTable
+		case tomlDottedTable:
+		default:
+			return syntaxErrorAt(c.content, start, "key '%s' is already defined", joinTOMLKey(parts))
+		}
+	}
+	path = joinTOMLPath(path, parts[len(parts)-1])
+	if c.defined[path] != 0 {
+		return syntaxErrorAt(c.content, start, "key '%s' is already defined", joinTOMLKey(parts))
+	}
+	c.defined[path] = tomlValue
+	return c.value(path)
+}
+
+// key parses simple and dotted keys and returns their parts
+func (c *tomlChecker) key() ([]string, *SyntaxError) {
+	var parts []string
+	for {
+		start := c.pos
+		switch c.peek() {
+		case '"':
+			if err := c.basicString(); err != nil {
+				return nil, err
+			}
+			raw := string(c.content[start:c.pos])
+			part, err := strconv.Unquote(raw)
+			if err != nil {
+				part = raw[1 : len(raw)-1]
+			}
+			parts = append(parts, part)
+		case '\'':
+			if err := c.literalString(); err != nil {
+				return nil, err
+			}
+			parts = append(parts, string(c.content[start+1:c.pos-1]))
+		default:
+			bare := tomlBareKey.Find(c.content[c.pos:])
+			if bare == nil {
+				return nil, c.errorf("invalid key")
+			}
+			c.pos += len(bare)
+			parts = append(parts, string(bare))
+		}
+		c.skipWhitespace()
+		if c.peek() != '.' {
+			return parts, nil
+		}
+		c.pos++
+		c.skipWhitespace()
+	}
+}
+
+// resolve appends a key to a path and points to the last entry if the path is an array of tables
+func (c *tomlChecker) resolve(path, part string) string {
+	if part != "" {
+		path = joinTOMLPath(path, part)
+	}
+	if c.defined[path] == tomlArrayTable {
+		path = joinTOMLPath(path, "["+strconv.Itoa(c.arrayTables[path])+"]")
+	}
+	return path
+}
+
+// value parses a value, path is used to scope the keys of inline tables
+func (c *tomlChecker) value(path string) *SyntaxError {
+	switch {
+	case c.consume(`"""`):
+		return c.multiLineString(`"""`, true)
+	case c.consume(`'''`):
+		return c.multiLineString(`'''`, false)
+	case c.peek() == '"':
+		return c.basicString()
+	case c.peek() == '\'':
+		return c.literalString()
+	case c.peek() == '[':
+		return c.array(path)
+	case c.peek() == '{':
+		return c.inlineTable(path)
+	}
+	scalar := tomlScalar.Find(c.content[c.pos:])
+	if scalar == nil {
+		return c.errorf("invalid value")
+	}
+	c.pos += len(scalar)
+	if !c.eof() && !isTOMLDelimiter(c.peek()) {
+		return c.errorf("invalid value")
+	}
+	return nil
+}
+
+func (c *tomlChecker) basicString() *SyntaxError {
+	c.pos++
+	for !c.eof() {
+		switch c.peek() {
+		case '"':
+			c.pos++
+			return nil
+		case '\n':
+			return c.errorf("unterminated string")
+		case '\\':
+			if err := c.escape(false); err != nil {
+				return err
+			}
+			continue
+		}
+		c.pos++
+	}
+	return c.errorf("unterminated string")
+}
+
+func (c *tomlChecker) literalString() *SyntaxError {
+	c.pos++
+	for !c.eof() {
+		switch c.peek() {
+		case '\'':
+			c.pos++
+			return nil
+		case '\n':
+			return c.errorf("unterminated string")
+		}
+		c.pos++
+	}
+	return c.errorf("unterminated string")
+}
+
+func (c *tomlChecker) multiLineString(delimiter string, escapes bool) *SyntaxError {
+	start := c.pos
+	for !c.eof() {
+		if c.consume(delimiter) {
+			// up to two quotes are allowed right before the closing delimiter
+			for i := 0; i < 2 && !c.eof() && c.peek() == delimiter[0]; i++ {
+				c.pos++
+			}
+			return nil
+		}
+		if escapes && c.peek() == '\\' {
+			if err := c.escape(true); err != nil {
+				return err
+			}
+			continue
+		}
+		c.pos++
+	}
+	c.pos = start
+	return c.errorf("unterminated multi-line string")
+}
+
+// escape validates an escape sequence in basic strings
+func (c *tomlChecker) escape(multiLine bool) *SyntaxError {
+	c.pos++
+	if c.eof() {
+		return c.errorf("invalid escape sequence")
+	}
+	switch c.peek() {
+	case 'b', 't', 'n', 'f', 'r', '"', '\\':
+		c.pos++
+		return nil
+	case 'u', 'U':
+		length := 4
+		if c.peek() == 'U' {
+			length = 8
+		}
+		c.pos++
+		for i := 0; i < length; i++ {
+			if c.eof() || !isHex(c.peek()) {
+				return c.errorf("invalid unicode escape sequence")
+			}
+			c.pos++
+		}
+		return nil
+	}
+	if multiLine {
+		// a line ending backslash trims all following whitespace
+		rest := c.pos
+		for rest < len(c.content) && (c.content[rest] == ' ' || c.content[rest] == '\t') {
+			rest++
+		}
+		if rest < len(c.content) && (c.content[rest] == '\n' || c.content[rest] == '\r') {
+			c.pos = rest
+			return nil
+		}
+	}
+	return c.errorf("invalid escape sequence")
+}
+
+func (c *tomlChecker) array(path string) *SyntaxError {
+	c.pos++
+	for i := 0; ; i++ {
+		c.skipWhitespaceCommentsAndNewlines()
+		if c.eof() {
+			return c.errorf("unterminated array")
+		}
+		if c.consume("]") {
+			return nil
+		}
+		if err := c.value(joinTOMLPath(path, "["+strconv.Itoa(i)+"]")); err != nil {
+			return err
+		}
+		c.skipWhitespaceCommentsAndNewlines()
+		if c.consume("]") {
+			return nil
+		}
+		if !c.consume(",") {
+			return c.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (c *tomlChecker) inlineTable(path string) *SyntaxError {
+	c.pos++
+	c.skipWhitespace()
+	if c.consume("}") {
+		return nil
+	}
+	for {
+		c.skipWhitespace()
+		if err := c.keyValue(path); err != nil {
+			return err
+		}
+		c.skipWhitespace()
+		if c.consume("}") {
+			return nil
+		}
+		if !c.consume(",") {
+			return c.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// lineEnd makes sure nothing but a comment follows a key value pair or table header
+func (c *tomlChecker) lineEnd() *SyntaxError {
+	c.skipWhitespace()
+	if c.eof() {
+		return nil
+	}
+	switch c.peek() {
+	case '#':
+		c.skipComment()
+		return nil
+	case '\r', '\n':
+		return nil
+	}
+	return c.errorf("expected a new line")
+}
+
+func (c *tomlChecker) skipWhitespace() {
+	for !c.eof() && (c.peek() == ' ' || c.peek() == '\t') {
+		c.pos++
+	}
+}
+
+func (c *tomlChecker) skipComment() {
+	for !c.eof() && c.peek() != '\n' {
+		c.pos++
+	}
+}
+
+func (c *tomlChecker) skipWhitespaceCommentsAndNewlines() {
+	for !c.eof() {
+		switch c.peek() {
+		case ' ', '\t', '\r', '\n':
+			c.pos++
+		case '#':
+			c.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (c *tomlChecker) consume(s string) bool {
+	if len(c.content)-c.pos >= len(s) && string(c.content[c.pos:c.pos+len(s)]) == s {
+		c.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (c *tomlChecker) peek() byte {
+	if c.eof() {
+		return 0
+	}
+	return c.content[c.pos]
+}
+
+func (c *tomlChecker) eof() bool {
+	return c.pos >= len(c.content)
+}
+
+func (c *tomlChecker) errorf(format string, args ...interface{}) *SyntaxError {
+	return syntaxErrorAt(c.content, c.pos, format, args...)
+}
+
+// joinTOMLPath joins key parts with a separator that can not be part of a key
+func joinTOMLPath(path, part string) string {
+	if path == "" {
+		return part
+	}
+	return path + "\x00" + part
+}
+
+func joinTOMLKey(parts []string) string {
+	return strings.Join(parts, ".")
+}
+
+func isTOMLDelimiter(b byte) bool {
+	switch b {
+	case ' ', '\t', '\r', '\n', ',', ']', '}', '#':
+		return true
+	}
+	return false
+}
+
+func isHex(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
@@ -0,0 +1,75 @@
+package interaction
+
+import (
+	"errors"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/app"
+	"github.com/captainhook-go/captainhook/hooks/placeholder"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// Confirm asks you to confirm an operation before it is executed.
+// The question can contain placeholders like {$BRANCH} or {$COMMIT_COUNT}. Use conditions to decide
+// when to ask. If no terminal is attached the 'default' answer is used.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Interaction.Confirm",
+//	  "options": {
+//	    "question": "You are pushing {$COMMIT_COUNT} commits to {$BRANCH}, continue?",
+//	    "default": "n"
+//	  },
+//	  "conditions": [
+//	    {"run": "CaptainHook::Status.OnBranch", "options": {"name": "main"}}
+//	  ]
+//	}
+type Confirm struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *Confirm) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *Confirm) Run(action *configuration.Action) error {
+	return a.confirm(action.Options())
+}
+
+func (a *Confirm) confirm(options *configuration.Options) error {
+	question := placeholder.ReplacePlaceholders(
+		app.NewContext(a.hookBundle.AppIO, a.hookBundle.Conf, a.hookBundle.Repo),
+		options.AsString("question", "Do you want to continue?"),
+	)
+	defaultAnswer := strings.ToLower(options.AsString("default", "n"))
+
+	answer := defaultAnswer
+	if a.hookBundle.AppIO.IsInteractive() {
+		answer = strings.ToLower(a.hookBundle.AppIO.Ask(question+" "+hint(defaultAnswer)+" ", defaultAnswer))
+	} else {
+		a.hookBundle.AppIO.Write("not interactive, using default answer '"+defaultAnswer+"'", true, io.VERBOSE)
+	}
+
+	if answer != "y" && answer != "yes" {
+		return errors.New("not confirmed: " + question)
+	}
+	return nil
+}
+
+// hint shows the possible answers with the default answer in upper case
+func hint(defaultAnswer string) string {
+	if defaultAnswer == "y" || defaultAnswer == "yes" {
+		return "<comment>[Y,n]</comment>"
+	}
+	return "<comment>[y,N]</comment>"
+}
+
+func NewConfirm(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := Confirm{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{}),
+	}
+	return &a
+}
@@ -0,0 +1,65 @@
+package interaction
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answers   []string
+		options   map[string]interface{}
+		confirmed bool
+	}{
+		{[]string{"y"}, map[string]interface{}{}, true},
+		{[]string{"Yes"}, map[string]interface{}{}, true},
+		{[]string{"n"}, map[string]interface{}{"default": "y"}, false},
+		{nil, map[string]interface{}{}, false},
+		{nil, map[string]interface{}{"default": "y"}, true},
+	}
+	for _, test := range tests {
+		err := confirmWith(test.answers, test.options)
+		if (err == nil) != test.confirmed {
+			t.Errorf("Answers %v with options %v should be confirmed: %t", test.answers, test.options, test.confirmed)
+		}
+	}
+}
+
+func TestConfirmRendersQuestion(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	repo := test.CreateFakeRepo().SetBranch("main")
+	a := NewConfirm(inOut, test.CreateFakeConfig(), repo).(*Confirm)
+
+	_ = a.confirm(configuration.NewOptions(map[string]interface{}{"question": "Commit to {$BRANCH}?"}))
+	if len(inOut.Out) != 1 || inOut.Out[0] != "Commit to main? <comment>[y,N]</comment> " {
+		t.Errorf("Question not rendered correctly, got: %v", inOut.Out)
+	}
+}
+
+func TestConfirmUsesDefaultWithoutTerminal(t *testing.T) {
+	for defaultAnswer, confirmed := range map[string]bool{"n": false, "y": true} {
+		inOut := test.CreateFakeIO()
+		inOut.SetInteractive(false)
+		inOut.SetAnswers([]string{"y"})
+		a := NewConfirm(inOut, test.CreateFakeConfig(), test.CreateFakeRepo()).(*Confirm)
+
+		err := a.confirm(configuration.NewOptions(map[string]interface{}{"default": defaultAnswer}))
+		if (err == nil) != confirmed {
+			t.Errorf("Default answer '%s' should be confirmed: %t", defaultAnswer, confirmed)
+		}
+		for _, out := range inOut.Out {
+			if strings.Contains(out, "?") {
+				t.Errorf("No question should be asked without a terminal, got: %s", out)
+			}
+		}
+	}
+}
+
+func confirmWith(answers []string, options map[string]interface{}) error {
+	inOut := test.CreateFakeIO()
+	inOut.SetAnswers(answers)
+	a := NewConfirm(inOut, test.CreateFakeConfig(), test.CreateFakeRepo()).(*Confirm)
+	return a.confirm(configuration.NewOptions(options))
+}
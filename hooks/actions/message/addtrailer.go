@@ -0,0 +1,121 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"sort"
+	"strings"
+)
+
+// AddTrailer adds trailers to your commit message.
+// Trailers already present in the message are not added again.
+// With 'signoff' enabled a 'Signed-off-by' trailer for the configured git user is added.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.AddTrailer",
+//	  "options": {
+//	    "trailers": {
+//	      "Reviewed-by": "Jane Doe <jane@example.com>",
+//	      "Refs": ["#123", "#456"]
+//	    },
+//	    "signoff": true
+//	  }
+//	}
+type AddTrailer struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *AddTrailer) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *AddTrailer) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("add commit message trailers", true, io.VERBOSE)
+
+	msgFile, msg, err := commitMessage(a.hookBundle)
+	if err != nil {
+		return err
+	}
+
+	trailers, err := a.configuredTrailers(action.Options())
+	if err != nil {
+		return err
+	}
+	newMsg := msg
+	for _, trailer := range trailers {
+		newMsg = newMsg.WithTrailer(trailer.Key, trailer.Value)
+	}
+	if newMsg == msg {
+		return nil
+	}
+	return a.hookBundle.Repo.PrepareCommitMessage(msgFile, newMsg)
+}
+
+// configuredTrailers returns all trailers to add sorted by key
+func (a *AddTrailer) configuredTrailers(options *configuration.Options) ([]*types.Trailer, error) {
+	var trailers []*types.Trailer
+
+	configured, ok := options.All()["trailers"]
+	if ok {
+		trailerMap, isMap := configured.(map[string]interface{})
+		if !isMap {
+			return nil, errors.New("option 'trailers' has to be an object")
+		}
+		var keys []string
+		for key := range trailerMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			switch value := trailerMap[key].(type) {
+			case string:
+				trailers = append(trailers, &types.Trailer{Key: key, Value: value})
+			case []interface{}:
+				for _, v := range value {
+					trailers = append(trailers, &types.Trailer{Key: key, Value: fmt.Sprint(v)})
+				}
+			default:
+				return nil, fmt.Errorf("invalid value for trailer '%s'", key)
+			}
+		}
+	}
+	if options.AsBool("signoff", false) {
+		identity, err := a.signOffIdentity()
+		if err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, &types.Trailer{Key: "Signed-off-by", Value: identity})
+	}
+	return trailers, nil
+}
+
+// signOffIdentity returns the configured git user to sign off with
+// Both 'user.name' and 'user.email' have to be set, otherwise the trailer would be useless.
+func (a *AddTrailer) signOffIdentity() (string, error) {
+	var missing []string
+	for _, key := range []string{"user.name", "user.email"} {
+		if strings.TrimSpace(a.hookBundle.Repo.ConfigValue(key, "")) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("can't sign off, git config is missing: %s", strings.Join(missing, ", "))
+	}
+	return gitIdentity(a.hookBundle.Repo), nil
+}
+
+func NewAddTrailer(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := AddTrailer{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrepareCommitMsg}),
+	}
+	return &a
+}
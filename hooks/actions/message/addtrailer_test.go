@@ -0,0 +1,35 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"testing"
+)
+
+func TestAddTrailerSignOff(t *testing.T) {
+	options := configuration.NewOptions(map[string]interface{}{"signoff": true})
+	tests := []struct {
+		config   map[string]string
+		expected string
+		err      string
+	}{
+		{map[string]string{"user.name": "Jane Doe", "user.email": "jane@example.com"}, "Jane Doe <jane@example.com>", ""},
+		{map[string]string{"user.name": "Jane Doe"}, "", "can't sign off, git config is missing: user.email"},
+		{map[string]string{}, "", "can't sign off, git config is missing: user.name, user.email"},
+	}
+	for _, tc := range tests {
+		repo := test.CreateFakeRepo().SetConfig(tc.config)
+		a := NewAddTrailer(test.CreateFakeIO(), test.CreateFakeConfig(), repo).(*AddTrailer)
+
+		trailers, err := a.configuredTrailers(options)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("Expected error '%s', got: %v", tc.err, err)
+			}
+			continue
+		}
+		if err != nil || len(trailers) != 1 || trailers[0].Value != tc.expected {
+			t.Errorf("Expected sign off by '%s', got: %v %v", tc.expected, trailers, err)
+		}
+	}
+}
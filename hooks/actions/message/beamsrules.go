@@ -1,14 +1,11 @@
 package message
 
 import (
-	"errors"
 	"github.com/captainhook-go/captainhook/configuration"
 	"github.com/captainhook-go/captainhook/git"
-	"github.com/captainhook-go/captainhook/git/types"
 	"github.com/captainhook-go/captainhook/hooks"
 	"github.com/captainhook-go/captainhook/info"
 	"github.com/captainhook-go/captainhook/io"
-	"strings"
 )
 
 // BeamsRules blocks commits if the commit message is not following these rules.
@@ -20,6 +17,8 @@ import (
 //   - Subject is written in imperative mood
 //   - There is an empty line between subject and body
 //
+// In 'pre-push' hooks the messages of all pushed commits are checked.
+//
 // Example configuration:
 //
 //	{
@@ -41,25 +40,7 @@ func (a *BeamsRules) IsApplicableFor(hook string) bool {
 func (a *BeamsRules) Run(action *configuration.Action) error {
 	a.hookBundle.AppIO.Write("checking beams rules", true, io.VERBOSE)
 
-	rulebook := a.setupRulebook(action)
-	commitMessageFile := a.hookBundle.AppIO.Argument(info.ArgCommitMsgFile, "")
-	if commitMessageFile == "" {
-		return errors.New("commit message file argument is missing")
-	}
-	msg, err := a.hookBundle.Repo.CommitMessage(commitMessageFile)
-	if err != nil {
-		return err
-	}
-
-	ok, messages := rulebook.IsFollowedBy(msg)
-	if !ok {
-		for _, message := range messages {
-			a.hookBundle.AppIO.Write(message, true, io.NORMAL)
-		}
-		a.outputMessage(msg)
-		return errors.New("commit message did not follow all rules")
-	}
-	return nil
+	return validateCommitMessage(a.hookBundle, a.setupRulebook(action))
 }
 
 func (a *BeamsRules) setupRulebook(action *configuration.Action) *Rulebook {
@@ -80,15 +61,9 @@ func (a *BeamsRules) setupRulebook(action *configuration.Action) *Rulebook {
 	return rulebook
 }
 
-func (a *BeamsRules) outputMessage(msg *types.CommitMessage) {
-	a.hookBundle.AppIO.Write("===========================[ commit message ]===========================", true, io.NORMAL)
-	a.hookBundle.AppIO.Write(msg.Message(), true, io.NORMAL)
-	a.hookBundle.AppIO.Write(strings.Repeat("=", 72), true, io.NORMAL)
-}
-
 func NewBeamsRules(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
 	a := BeamsRules{
-		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg}),
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
 	}
 	return &a
 }
@@ -12,6 +12,7 @@ import (
 )
 
 // ContainsRegex blocks commits if the commit message is not matching the given regex.
+// In 'pre-push' hooks the messages of all pushed commits are checked.
 //
 // Example configuration:
 //
@@ -32,25 +33,25 @@ func (a *ContainsRegex) IsApplicableFor(hook string) bool {
 func (a *ContainsRegex) Run(action *configuration.Action) error {
 	a.hookBundle.AppIO.Write("checking regex", true, io.VERBOSE)
 
-	commitMessageFile := a.hookBundle.AppIO.Argument(info.ArgCommitMsgFile, "")
-	msg, err := a.hookBundle.Repo.CommitMessage(commitMessageFile)
-	if err != nil {
-		return err
-	}
 	regex := action.Options().AsString("regex", "")
 	if regex == "" {
 		return errors.New("option 'regex' is missing")
 	}
-	match, _ := regexp.MatchString(regex, msg.Message())
-	if !match {
-		return fmt.Errorf("unable to find '%s' in commit message", regex)
+	r, err := regexp.Compile(regex)
+	if err != nil {
+		return fmt.Errorf("invalid regex '%s': %s", regex, err.Error())
 	}
-	return nil
+	return checkCommits(a.hookBundle, func(c *commitToCheck) []string {
+		if !r.MatchString(c.msg.Message()) {
+			return []string{fmt.Sprintf("unable to find '%s' in commit message", regex)}
+		}
+		return nil
+	})
 }
 
 func NewContainsRegex(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
 	a := ContainsRegex{
-		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg}),
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
 	}
 	return &a
 }
@@ -0,0 +1,102 @@
+package message
+
+import (
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"slices"
+	"strings"
+)
+
+var DefaultConventionalTypes = []string{
+	"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+}
+
+// MustFollowConventionalCommits blocks commits if the commit message is not following the
+// conventional commits specification https://www.conventionalcommits.org
+// You can restrict the allowed types and scopes and require every commit to have a scope.
+// Merge, revert, fixup and squash commits are not validated.
+// In 'pre-push' hooks the messages of all pushed commits are checked.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.MustFollowConventionalCommits",
+//	  "options: {
+//	    "types": ["feat", "fix", "docs", "chore"],
+//	    "scopes": ["api", "cli"],
+//	    "require-scope": true
+//	  }
+//	}
+type MustFollowConventionalCommits struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *MustFollowConventionalCommits) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *MustFollowConventionalCommits) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking conventional commits", true, io.VERBOSE)
+
+	return checkCommits(a.hookBundle, func(c *commitToCheck) []string {
+		if c.msg.IsMerge() || c.msg.IsRevert() || c.msg.IsFixup() || c.msg.IsSquash() {
+			a.hookBundle.AppIO.Write("  skipped merge, revert, fixup or squash commit", true, io.VERBOSE)
+			return nil
+		}
+		return a.validate(c.msg, action.Options())
+	})
+}
+
+// validate returns all problems found in the commit message
+func (a *MustFollowConventionalCommits) validate(msg *types.CommitMessage, options *configuration.Options) []string {
+	commit, err := types.ParseConventionalCommit(msg)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var problems []string
+	allowedTypes := options.AsSliceOfStrings("types")
+	if len(allowedTypes) == 0 {
+		allowedTypes = DefaultConventionalTypes
+	}
+	if !slices.Contains(allowedTypes, commit.Type) {
+		problems = append(
+			problems,
+			fmt.Sprintf("type '%s' is not allowed, use one of: %s", commit.Type, strings.Join(allowedTypes, ", ")),
+		)
+	}
+
+	allowedScopes := options.AsSliceOfStrings("scopes")
+	if commit.Scope == "" {
+		if options.AsBool("require-scope", false) {
+			problems = append(problems, "scope is missing, use 'type(scope): description'")
+		}
+	} else if len(allowedScopes) > 0 && !slices.Contains(allowedScopes, commit.Scope) {
+		problems = append(
+			problems,
+			fmt.Sprintf("scope '%s' is not allowed, use one of: %s", commit.Scope, strings.Join(allowedScopes, ", ")),
+		)
+	}
+
+	if strings.TrimSpace(commit.Description) != commit.Description {
+		problems = append(problems, "description must not start or end with whitespace")
+	}
+	for _, note := range commit.BreakingNotes() {
+		if strings.TrimSpace(note) == "" {
+			problems = append(problems, "'BREAKING CHANGE' footer needs a description")
+		}
+	}
+	return problems
+}
+
+func NewMustFollowConventionalCommits(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := MustFollowConventionalCommits{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
+	}
+	return &a
+}
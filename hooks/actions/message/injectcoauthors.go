@@ -0,0 +1,58 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+)
+
+// InjectCoAuthors adds a 'Co-authored-by' trailer for every co-author of the current pairing session.
+// Sessions are started with `captainhook pair <alias...>` and ended with `captainhook pair --clear`.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.InjectCoAuthors"
+//	}
+type InjectCoAuthors struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *InjectCoAuthors) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *InjectCoAuthors) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("inject co-authors", true, io.VERBOSE)
+
+	session, err := LoadPairSession(a.hookBundle.Repo.GitDir())
+	if err != nil {
+		return err
+	}
+	if !session.IsActive() {
+		a.hookBundle.AppIO.Write("  no active pairing session", true, io.VERBOSE)
+		return nil
+	}
+
+	msgFile, msg, err := commitMessage(a.hookBundle)
+	if err != nil {
+		return err
+	}
+	newMsg := msg
+	for _, coAuthor := range session.CoAuthors {
+		newMsg = newMsg.WithTrailer("Co-authored-by", coAuthor)
+	}
+	if newMsg == msg {
+		return nil
+	}
+	return a.hookBundle.Repo.PrepareCommitMessage(msgFile, newMsg)
+}
+
+func NewInjectCoAuthors(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := InjectCoAuthors{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrepareCommitMsg}),
+	}
+	return &a
+}
@@ -0,0 +1,165 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultIssueKeyRegex = `\b([A-Z][A-Z0-9]+-[0-9]+)\b`
+)
+
+// nonIssuePrefixes are prefixes of well known identifiers like UTF-8 or SHA-256 that look like issue keys
+var nonIssuePrefixes = []string{"CVE", "CWE", "ECMA", "IEEE", "ISO", "RFC", "SHA", "UTF"}
+
+// IssueKeyExists blocks commits if the commit message references issues that don't exist.
+// Keys can be checked against an issue tracker. If 'projects' are configured only keys of these
+// projects are checked, otherwise identifiers like UTF-8, SHA-256 or ISO-8601 are ignored.
+// The tracker 'url' has to contain the {$KEY} placeholder. Header values can use environment
+// variables like ${JIRA_TOKEN} so no credentials have to be stored in the configuration.
+// Existing keys are cached in the git directory for 'cache-ttl' seconds.
+// If the tracker is not reachable within 'timeout' seconds the key is accepted,
+// unless 'offline' is set to 'block'.
+// In 'pre-push' hooks the messages of all pushed commits are checked.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.IssueKeyExists",
+//	  "options": {
+//	    "regex": "\\b([A-Z][A-Z0-9]+-[0-9]+)\\b",
+//	    "projects": ["ABC", "OPS"],
+//	    "url": "https://jira.example.com/rest/api/2/issue/{$KEY}",
+//	    "headers": {"Authorization": "Bearer ${JIRA_TOKEN}"},
+//	    "timeout": 3,
+//	    "cache-ttl": 86400,
+//	    "offline": "allow",
+//	    "require-key": true
+//	  }
+//	}
+type IssueKeyExists struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *IssueKeyExists) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *IssueKeyExists) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking issue keys", true, io.VERBOSE)
+
+	options := action.Options()
+	pattern := options.AsString("regex", DefaultIssueKeyRegex)
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regex '%s': %s", pattern, err.Error())
+	}
+	tracker, err := a.setupTracker(options)
+	if err != nil {
+		return err
+	}
+	projects := options.AsSliceOfStrings("projects")
+	blockOffline := options.AsString("offline", "allow") == "block"
+
+	err = checkCommits(a.hookBundle, func(c *commitToCheck) []string {
+		keys := a.findKeys(r, c.msg.Message(), projects)
+		if len(keys) == 0 && options.AsBool("require-key", false) {
+			if len(projects) > 0 {
+				return []string{"no issue key of the projects " + strings.Join(projects, ", ") + " found"}
+			}
+			return []string{"no issue key found"}
+		}
+		var problems []string
+		for _, key := range keys {
+			if tracker == nil {
+				continue
+			}
+			exists, checkErr := tracker.Exists(key)
+			if checkErr != nil {
+				a.hookBundle.AppIO.Write("<comment>could not check '"+key+"': "+checkErr.Error()+"</comment>", true, io.NORMAL)
+				if blockOffline {
+					problems = append(problems, fmt.Sprintf("issue '%s' could not be verified", key))
+				}
+				continue
+			}
+			if !exists {
+				problems = append(problems, fmt.Sprintf("issue '%s' does not exist", key))
+			}
+		}
+		return problems
+	})
+	if tracker != nil && tracker.cache != nil {
+		if saveErr := tracker.cache.Save(); saveErr != nil {
+			a.hookBundle.AppIO.Write("could not write issue key cache", true, io.VERBOSE)
+		}
+	}
+	return err
+}
+
+// setupTracker creates the IssueTracker if an url is configured
+func (a *IssueKeyExists) setupTracker(options *configuration.Options) (*IssueTracker, error) {
+	urlTemplate := options.AsString("url", "")
+	if urlTemplate == "" {
+		return nil, nil
+	}
+	if !strings.Contains(urlTemplate, "{$KEY}") {
+		return nil, errors.New("option 'url' has to contain the {$KEY} placeholder")
+	}
+	headers := map[string]string{}
+	if configured, ok := options.All()["headers"].(map[string]interface{}); ok {
+		for name, value := range configured {
+			headers[name] = fmt.Sprint(value)
+		}
+	}
+	timeout := time.Duration(options.AsFloat("timeout", 3) * float64(time.Second))
+	tracker := NewIssueTracker(urlTemplate, headers, timeout)
+
+	ttl := time.Duration(options.AsInt("cache-ttl", 86400)) * time.Second
+	if ttl > 0 {
+		cache, err := LoadIssueKeyCache(IssueKeyCachePath(a.hookBundle.Repo.GitDir()), ttl)
+		if err != nil {
+			return nil, err
+		}
+		tracker.UseCache(cache)
+	}
+	return tracker, nil
+}
+
+// findKeys returns all unique keys matched by the first regex group or the complete match
+// Only keys of the given projects are returned, without projects well known identifiers are skipped.
+func (a *IssueKeyExists) findKeys(r *regexp.Regexp, text string, projects []string) []string {
+	var keys []string
+	for _, match := range r.FindAllStringSubmatch(text, -1) {
+		key := match[0]
+		if len(match) > 1 {
+			key = match[1]
+		}
+		project, _, _ := strings.Cut(key, "-")
+		if len(projects) > 0 && !slices.Contains(projects, project) {
+			continue
+		}
+		if len(projects) == 0 && slices.Contains(nonIssuePrefixes, project) {
+			continue
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func NewIssueKeyExists(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := IssueKeyExists{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
+	}
+	return &a
+}
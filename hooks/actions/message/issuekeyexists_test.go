@@ -0,0 +1,30 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/test"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestIssueKeyExistsFindKeys(t *testing.T) {
+	a := NewIssueKeyExists(test.CreateFakeIO(), test.CreateFakeConfig(), test.CreateFakeRepo()).(*IssueKeyExists)
+	r := regexp.MustCompile(DefaultIssueKeyRegex)
+	text := "ABC-12 Store dates as ISO-8601 and UTF-8\n\nUse SHA-256 for OPS-3, see ABC-12 and XY-7"
+
+	tests := []struct {
+		projects []string
+		expected []string
+	}{
+		{nil, []string{"ABC-12", "OPS-3", "XY-7"}},
+		{[]string{"ABC", "OPS"}, []string{"ABC-12", "OPS-3"}},
+		{[]string{"UTF"}, []string{"UTF-8"}},
+		{[]string{"NOPE"}, nil},
+	}
+	for _, tc := range tests {
+		keys := a.findKeys(r, text, tc.projects)
+		if !slices.Equal(keys, tc.expected) {
+			t.Errorf("Wrong keys for projects %v, expected %v got: %v", tc.projects, tc.expected, keys)
+		}
+	}
+}
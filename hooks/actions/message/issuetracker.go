@@ -0,0 +1,127 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// IssueTracker checks if issue keys exist by requesting an HTTP endpoint
+// The endpoint is defined by an url template containing the {$KEY} placeholder.
+// A 2xx response means the issue exists, a 404 response means it does not exist.
+// Existing keys are cached on disk, so they don't have to be requested again until the cache expires.
+type IssueTracker struct {
+	urlTemplate string
+	headers     map[string]string
+	client      *http.Client
+	cache       *IssueKeyCache
+}
+
+// Exists tells you if an issue key exists
+// If the tracker can't be reached or answers with an unexpected status code an error is returned.
+func (t *IssueTracker) Exists(key string) (bool, error) {
+	if t.cache != nil && t.cache.Contains(key) {
+		return true, nil
+	}
+	request, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(t.urlTemplate, "{$KEY}", url.PathEscape(key)), nil)
+	if err != nil {
+		return false, fmt.Errorf("invalid issue tracker url: %s", err.Error())
+	}
+	for name, value := range t.headers {
+		request.Header.Set(name, os.ExpandEnv(value))
+	}
+	response, err := t.client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("issue tracker not reachable: %s", err.Error())
+	}
+	_ = response.Body.Close()
+
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode < 300:
+		if t.cache != nil {
+			t.cache.Add(key)
+		}
+		return true, nil
+	case response.StatusCode == http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected issue tracker response: %s", response.Status)
+}
+
+// UseCache makes the tracker remember existing keys
+func (t *IssueTracker) UseCache(cache *IssueKeyCache) {
+	t.cache = cache
+}
+
+func NewIssueTracker(urlTemplate string, headers map[string]string, timeout time.Duration) *IssueTracker {
+	return &IssueTracker{
+		urlTemplate: urlTemplate,
+		headers:     headers,
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+// IssueKeyCache stores existing issue keys with the time they were checked
+//
+//	{"ABC-123": "2024-01-01T12:00:00Z"}
+type IssueKeyCache struct {
+	path    string
+	ttl     time.Duration
+	checked map[string]time.Time
+}
+
+// Contains tells you if a key is cached and not expired
+func (c *IssueKeyCache) Contains(key string) bool {
+	checked, ok := c.checked[key]
+	return ok && time.Since(checked) < c.ttl
+}
+
+// Add caches an existing key
+func (c *IssueKeyCache) Add(key string) {
+	c.checked[key] = time.Now()
+}
+
+// Save writes all not expired keys to the cache file
+func (c *IssueKeyCache) Save() error {
+	for key := range c.checked {
+		if !c.Contains(key) {
+			delete(c.checked, key)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c.checked, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// IssueKeyCachePath returns the path to the cache file
+func IssueKeyCachePath(gitDir string) string {
+	return filepath.Join(gitDir, "captainhook", "issue-keys.json")
+}
+
+// LoadIssueKeyCache reads the cache file
+// If the file does not exist or is broken an empty cache is returned.
+func LoadIssueKeyCache(path string, ttl time.Duration) (*IssueKeyCache, error) {
+	c := &IssueKeyCache{path: path, ttl: ttl, checked: map[string]time.Time{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, err
+	}
+	if jsonErr := json.Unmarshal(data, &c.checked); jsonErr != nil {
+		c.checked = map[string]time.Time{}
+	}
+	return c, nil
+}
@@ -0,0 +1,71 @@
+package message
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIssueTrackerExists(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/issue/ABC-1" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("TRACKER_TOKEN", "secret")
+	tracker := NewIssueTracker(server.URL+"/issue/{$KEY}", map[string]string{"Authorization": "Bearer ${TRACKER_TOKEN}"}, time.Second)
+	cache, _ := LoadIssueKeyCache(filepath.Join(t.TempDir(), "issue-keys.json"), time.Hour)
+	tracker.UseCache(cache)
+
+	if exists, err := tracker.Exists("ABC-1"); !exists || err != nil {
+		t.Errorf("ABC-1 should exist, got error: %v", err)
+	}
+	if exists, err := tracker.Exists("ABC-2"); exists || err != nil {
+		t.Errorf("ABC-2 should not exist, got error: %v", err)
+	}
+	// the existing key is cached now
+	_, _ = tracker.Exists("ABC-1")
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestIssueTrackerOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	tracker := NewIssueTracker(server.URL+"/issue/{$KEY}", map[string]string{}, time.Second)
+	if _, err := tracker.Exists("ABC-1"); err == nil {
+		t.Errorf("Unreachable tracker should return an error")
+	}
+}
+
+func TestIssueKeyCacheSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "captainhook", "issue-keys.json")
+	cache, _ := LoadIssueKeyCache(path, time.Hour)
+	cache.Add("ABC-1")
+	if err := cache.Save(); err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+
+	loaded, _ := LoadIssueKeyCache(path, time.Hour)
+	if !loaded.Contains("ABC-1") {
+		t.Errorf("ABC-1 should be cached")
+	}
+	expired, _ := LoadIssueKeyCache(path, time.Nanosecond)
+	if expired.Contains("ABC-1") {
+		t.Errorf("ABC-1 should be expired")
+	}
+}
@@ -0,0 +1,56 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+)
+
+// MustFollowRules blocks commits if the commit message is not following the configured rules.
+// Other than MustFollowBeamsRules you can pick the rules you want to use and configure each of them.
+// Available rules are 'msg-not-empty', 'capitalize-subject', 'subject-length', 'body-line-length',
+// 'no-period-on-subject-end', 'separate-subject-from-body' and 'imperative'.
+// Additionally, you can define custom regex rules with their own error hints.
+// In 'pre-push' hooks the messages of all pushed commits are checked.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.MustFollowRules",
+//	  "options: {
+//	    "msg-not-empty": true,
+//	    "subject-length": 72,
+//	    "body-line-length": 100,
+//	    "imperative": {"beginning-only": true},
+//	    "custom": [
+//	      {"regex": "^[A-Z]+-[0-9]+ ", "in": "subject", "hint": "subject has to start with an issue key"},
+//	      {"regex": "(?i)wip", "match": false, "hint": "don't commit work in progress"}
+//	    ]
+//	  }
+//	}
+type MustFollowRules struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *MustFollowRules) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *MustFollowRules) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking commit message rules", true, io.VERBOSE)
+
+	rulebook, err := NewRulebookFromOptions(action.Options())
+	if err != nil {
+		return err
+	}
+	return validateCommitMessage(a.hookBundle, rulebook)
+}
+
+func NewMustFollowRules(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := MustFollowRules{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
+	}
+	return &a
+}
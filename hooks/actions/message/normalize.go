@@ -0,0 +1,81 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// Normalize fixes the formatting of your commit message instead of rejecting it.
+// It trims trailing whitespace, collapses blank lines, capitalizes the subject and wraps
+// the body paragraphs to 'body-line-length' characters. Code blocks, lists, quotes, urls and
+// trailers are left alone. Set 'body-line-length' to 0 to disable the wrapping.
+// Comment lines are kept at the end of the message.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.Normalize",
+//	  "options": {
+//	    "body-line-length": 72,
+//	    "capitalize-subject": true
+//	  }
+//	}
+type Normalize struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *Normalize) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *Normalize) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("normalize commit message", true, io.VERBOSE)
+
+	msgFile := a.hookBundle.AppIO.Argument(info.ArgCommitMsgFile, "")
+	msg, err := a.hookBundle.Repo.CommitMessage(msgFile)
+	if err != nil {
+		return err
+	}
+	if msg.IsEmpty() {
+		return nil
+	}
+
+	normalizer := NewNormalizer(
+		action.Options().AsInt("body-line-length", 72),
+		action.Options().AsBool("capitalize-subject", true),
+	)
+	content := msg.LinesWithoutTrailers()
+	lines := normalizer.Normalize(content, msg.Lines()[len(content):])
+
+	var comments []string
+	inScissors := false
+	for _, line := range io.SplitLines(msg.Raw()) {
+		if strings.Contains(line, "------------------------ >8 ------------------------") {
+			inScissors = true
+		}
+		if inScissors || strings.HasPrefix(line, msg.CommentChar()) {
+			comments = append(comments, line)
+		}
+	}
+
+	raw := strings.Join(lines, "\n") + "\n"
+	if len(comments) > 0 {
+		raw += strings.Join(comments, "\n") + "\n"
+	}
+	if raw == msg.Raw() {
+		return nil
+	}
+	return a.hookBundle.Repo.PrepareCommitMessage(msgFile, types.NewCommitMessage(raw, msg.CommentChar()))
+}
+
+func NewNormalize(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := Normalize{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrepareCommitMsg}),
+	}
+	return &a
+}
@@ -0,0 +1,156 @@
+package message
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	listItem           = regexp.MustCompile(`^\s*([-*+]|[0-9]+[.)])\s`)
+	conventionalPrefix = regexp.MustCompile(`^[A-Za-z]+(\([^()]*\))?!?: `)
+)
+
+// Normalizer cleans up commit messages
+//   - trims trailing whitespace
+//   - removes leading, trailing and repeated blank lines
+//   - separates subject and body with a blank line
+//   - capitalizes the subject
+//   - wraps body paragraphs to a maximum line length
+//
+// Code blocks, lists, quotes and trailers are not wrapped.
+type Normalizer struct {
+	width             int
+	capitalizeSubject bool
+}
+
+// Normalize returns the normalized message lines
+// The trailers have to be passed separately, so they are kept untouched.
+func (n *Normalizer) Normalize(lines []string, trailers []string) []string {
+	var cleaned []string
+	for _, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		// collapse blank lines and remove leading blank lines
+		if line == "" && (len(cleaned) == 0 || cleaned[len(cleaned)-1] == "") {
+			continue
+		}
+		cleaned = append(cleaned, line)
+	}
+	for len(cleaned) > 0 && cleaned[len(cleaned)-1] == "" {
+		cleaned = cleaned[:len(cleaned)-1]
+	}
+	if len(cleaned) == 0 {
+		return trailerLines(nil, trailers)
+	}
+
+	subject := cleaned[0]
+	if n.capitalizeSubject {
+		subject = capitalize(subject)
+	}
+	out := []string{subject}
+	body := cleaned[1:]
+	if len(body) > 0 && body[0] == "" {
+		body = body[1:]
+	}
+	if len(body) > 0 {
+		out = append(out, "")
+		out = append(out, n.wrapBody(body)...)
+	}
+	return trailerLines(out, trailers)
+}
+
+// wrapBody wraps all paragraphs of the body that are not code, lists or quotes
+func (n *Normalizer) wrapBody(lines []string) []string {
+	var out []string
+	var paragraph []string
+	inFence := false
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			out = append(out, n.wrapParagraph(paragraph)...)
+			paragraph = nil
+		}
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			flush()
+			inFence = !inFence
+			out = append(out, line)
+			continue
+		}
+		if inFence || line == "" {
+			flush()
+			out = append(out, line)
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	flush()
+	return out
+}
+
+// wrapParagraph reflows a paragraph to the configured width
+// Paragraphs containing code, list items or quotes are returned unchanged.
+func (n *Normalizer) wrapParagraph(lines []string) []string {
+	if n.width <= 0 {
+		return lines
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") ||
+			strings.HasPrefix(line, ">") || listItem.MatchString(line) {
+			return lines
+		}
+	}
+
+	var wrapped []string
+	current := ""
+	for _, word := range strings.Fields(strings.Join(lines, " ")) {
+		// long words like urls are never split
+		if current != "" && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > n.width {
+			wrapped = append(wrapped, current)
+			current = ""
+		}
+		if current == "" {
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+	if current != "" {
+		wrapped = append(wrapped, current)
+	}
+	return wrapped
+}
+
+// trailerLines adds the trailers separated by a blank line
+func trailerLines(lines []string, trailers []string) []string {
+	var cleaned []string
+	for _, trailer := range trailers {
+		if trailer = strings.TrimRightFunc(trailer, unicode.IsSpace); trailer != "" {
+			cleaned = append(cleaned, trailer)
+		}
+	}
+	if len(cleaned) == 0 {
+		return lines
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	return append(lines, cleaned...)
+}
+
+// capitalize uppercases the first letter of a subject
+// Conventional commit subjects, fixup and squash subjects are not changed.
+func capitalize(subject string) string {
+	if conventionalPrefix.MatchString(subject) ||
+		strings.HasPrefix(subject, "fixup!") || strings.HasPrefix(subject, "squash!") {
+		return subject
+	}
+	r, size := utf8.DecodeRuneInString(subject)
+	return string(unicode.ToUpper(r)) + subject[size:]
+}
+
+func NewNormalizer(width int, capitalizeSubject bool) *Normalizer {
+	return &Normalizer{width: width, capitalizeSubject: capitalizeSubject}
+}
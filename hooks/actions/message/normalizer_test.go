@@ -0,0 +1,54 @@
+package message
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		trailers []string
+		expected []string
+	}{
+		{
+			"cleanup",
+			[]string{"", "fix the login  ", "body line", "", "", "", "more"},
+			nil,
+			[]string{"Fix the login", "", "body line", "", "more"},
+		},
+		{
+			"wrap",
+			[]string{"Subject", "", "one two three four five six"},
+			nil,
+			[]string{"Subject", "", "one two", "three four", "five six"},
+		},
+		{
+			"keep lists and code",
+			[]string{"Subject", "", "- one two three four", "", "    code code code code", "", "```", "one two three four", "```"},
+			nil,
+			[]string{"Subject", "", "- one two three four", "", "    code code code code", "", "```", "one two three four", "```"},
+		},
+		{
+			"keep urls",
+			[]string{"Subject", "", "see https://example.com/very/long/url"},
+			nil,
+			[]string{"Subject", "", "see", "https://example.com/very/long/url"},
+		},
+		{
+			"conventional subject",
+			[]string{"feat(api): add endpoint"},
+			[]string{"Signed-off-by: Some Developer With A Long Name <dev@example.com>"},
+			[]string{"feat(api): add endpoint", "", "Signed-off-by: Some Developer With A Long Name <dev@example.com>"},
+		},
+	}
+	normalizer := NewNormalizer(10, true)
+	for _, test := range tests {
+		result := normalizer.Normalize(test.lines, test.trailers)
+		if !slices.Equal(result, test.expected) {
+			t.Errorf("%s: got:\n%s", test.name, strings.Join(result, "\n"))
+		}
+	}
+}
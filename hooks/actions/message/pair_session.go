@@ -0,0 +1,129 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultCoAuthorsFile = ".git-coauthors"
+	DefaultPairDuration  = 8 * time.Hour
+)
+
+// PairSession stores the co-authors of the current pairing or mob session
+// The session is stored in '.git/captainhook/pair' and is ignored once it expired.
+//
+//	{"coAuthors": ["Jane Doe <jane@example.com>"], "expires": "2024-01-01T18:00:00Z"}
+type PairSession struct {
+	CoAuthors []string  `json:"coAuthors"`
+	Expires   time.Time `json:"expires"`
+}
+
+// IsActive tells you if the session has co-authors and is not expired
+func (s *PairSession) IsActive() bool {
+	return len(s.CoAuthors) > 0 && time.Now().Before(s.Expires)
+}
+
+// Save writes the session to the repositories git directory
+func (s *PairSession) Save(gitDir string) error {
+	path := PairSessionPath(gitDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// NewPairSession creates a session for the given co-authors expiring after the given duration
+func NewPairSession(coAuthors []string, duration time.Duration) *PairSession {
+	return &PairSession{CoAuthors: coAuthors, Expires: time.Now().Add(duration)}
+}
+
+// PairSessionPath returns the path to the session file
+func PairSessionPath(gitDir string) string {
+	return filepath.Join(gitDir, "captainhook", "pair")
+}
+
+// LoadPairSession reads the current session
+// If there is no session an empty session is returned.
+func LoadPairSession(gitDir string) (*PairSession, error) {
+	session := &PairSession{}
+	data, err := os.ReadFile(PairSessionPath(gitDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return session, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, session); err != nil {
+		return nil, fmt.Errorf("invalid pair session file: %s", err.Error())
+	}
+	return session, nil
+}
+
+// ClearPairSession removes the current session
+func ClearPairSession(gitDir string) error {
+	err := os.Remove(PairSessionPath(gitDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// CoAuthors maps aliases to co-authors 'Name <email>' loaded from a team file
+// The team file uses the '.git-coauthors' format.
+//
+//	{
+//	  "coauthors": {
+//	    "jd": {"name": "Jane Doe", "email": "jane@example.com"}
+//	  }
+//	}
+type CoAuthors struct {
+	authors map[string]string
+}
+
+// Resolve returns the co-author for an alias
+// Values already looking like 'Name <email>' are returned unchanged.
+func (c *CoAuthors) Resolve(alias string) (string, error) {
+	if author, ok := c.authors[alias]; ok {
+		return author, nil
+	}
+	if strings.Contains(alias, "<") && strings.HasSuffix(alias, ">") {
+		return alias, nil
+	}
+	return "", fmt.Errorf("unknown co-author alias '%s'", alias)
+}
+
+// LoadCoAuthors reads a team file
+// If the file does not exist no aliases are available.
+func LoadCoAuthors(path string) (*CoAuthors, error) {
+	c := &CoAuthors{authors: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, err
+	}
+	var file struct {
+		CoAuthors map[string]struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"coauthors"`
+	}
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid co-authors file '%s': %s", path, err.Error())
+	}
+	for alias, author := range file.CoAuthors {
+		c.authors[alias] = author.Name + " <" + author.Email + ">"
+	}
+	return c, nil
+}
@@ -0,0 +1,53 @@
+package message
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPairSessionSaveAndLoad(t *testing.T) {
+	gitDir := t.TempDir()
+	if err := NewPairSession([]string{"Jane Doe <jane@example.com>"}, time.Hour).Save(gitDir); err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+	session, err := LoadPairSession(gitDir)
+	if err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+	if !session.IsActive() || session.CoAuthors[0] != "Jane Doe <jane@example.com>" {
+		t.Errorf("Session should be active, got: %+v", session)
+	}
+
+	_ = ClearPairSession(gitDir)
+	session, _ = LoadPairSession(gitDir)
+	if session.IsActive() {
+		t.Errorf("Session should be cleared")
+	}
+}
+
+func TestPairSessionExpired(t *testing.T) {
+	if NewPairSession([]string{"Jane Doe <jane@example.com>"}, -time.Minute).IsActive() {
+		t.Errorf("Expired session should not be active")
+	}
+}
+
+func TestCoAuthorsResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultCoAuthorsFile)
+	_ = os.WriteFile(path, []byte(`{"coauthors": {"jd": {"name": "Jane Doe", "email": "jane@example.com"}}}`), 0644)
+
+	coAuthors, err := LoadCoAuthors(path)
+	if err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+	if author, _ := coAuthors.Resolve("jd"); author != "Jane Doe <jane@example.com>" {
+		t.Errorf("Wrong co-author, got '%s'", author)
+	}
+	if author, _ := coAuthors.Resolve("Bob <bob@example.com>"); author != "Bob <bob@example.com>" {
+		t.Errorf("Full co-author should be kept, got '%s'", author)
+	}
+	if _, err = coAuthors.Resolve("xy"); err == nil {
+		t.Errorf("Unknown alias should fail")
+	}
+}
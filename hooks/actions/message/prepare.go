@@ -57,7 +57,7 @@ func (a *Prepare) createCommitMessage(action *configuration.Action, msg *types.C
 }
 
 func NewPrepare(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
-	a := PrepareFromFile{
+	a := Prepare{
 		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrepareCommitMsg}),
 	}
 	return &a
@@ -0,0 +1,250 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	// DefaultBranchRegex splits branch names like 'feature/ABC-123-add-login' into type, key and slug
+	DefaultBranchRegex = `^(?:(?P<type>[^/]+)/)?(?:(?P<key>[A-Z][A-Z0-9]*-[0-9]+)-?)?(?P<slug>.*)$`
+	// SourceNone is used for commits without any message source like a plain `git commit`
+	SourceNone = "none"
+)
+
+// TemplateData is the data available in commit message templates
+//
+//	{{.Message}}        current commit message without comments, e.g. from 'git commit -m'
+//	{{.Branch}}         feature/ABC-123-add-login
+//	{{.Match.type}}     feature
+//	{{.Match.key}}      ABC-123
+//	{{.Match.slug}}     add-login
+//	{{.Author}}         Jane Doe
+//	{{.Email}}          jane@example.com
+//	{{.Files}}          list of staged files
+//	{{.Stats.Files}}    number of staged files
+//	{{.Stats.Added}}    number of added lines
+//	{{.Stats.Removed}}  number of removed lines
+//	{{.Scopes}}         sorted list of top level directories containing staged files
+type TemplateData struct {
+	Message string
+	Branch  string
+	Match   map[string]string
+	Author  string
+	Email   string
+	Files   []string
+	Stats   TemplateStats
+	Scopes  []string
+}
+
+// TemplateStats contains the numbers of the staged changes
+type TemplateStats struct {
+	Files   int
+	Added   int
+	Removed int
+}
+
+var templateFunctions = template.FuncMap{
+	"join":     strings.Join,
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"replace":  strings.ReplaceAll,
+	"humanize": func(s string) string { return strings.NewReplacer("-", " ", "_", " ").Replace(s) },
+}
+
+// PrepareFromTemplate prepares your commit message by rendering a Go text/template.
+// The template can access the parts of the branch name matched by the named groups of 'branch-regex',
+// the author, the staged files and their stats and the changed scopes, see TemplateData for details.
+// The message is only prepared if the commit message source is one of the configured 'sources'.
+// Possible sources are 'none', 'message', 'template', 'merge', 'squash' and 'commit', by default
+// only commits without any source are prepared. This way amends and merges keep their existing message.
+// Existing messages, e.g. from 'git commit -m', merges or amends, are kept unless the template
+// includes them with '{{.Message}}'. In that case the rendered template replaces the message, comments are kept.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.PrepareFromTemplate",
+//	  "options": {
+//	    "template": "{{.Match.type}}({{join .Scopes \",\"}}): {{humanize .Match.slug}}\n\nRefs: {{.Match.key}}",
+//	    "file": "commit-template.tpl",
+//	    "branch-regex": "^(?P<type>[a-z]+)/(?P<key>[A-Z]+-[0-9]+)-(?P<slug>.*)$",
+//	    "sources": ["none", "template"]
+//	  }
+//	}
+type PrepareFromTemplate struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *PrepareFromTemplate) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *PrepareFromTemplate) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("prepare commit message from template", true, io.VERBOSE)
+	return a.prepare(action.Options())
+}
+
+func (a *PrepareFromTemplate) prepare(options *configuration.Options) error {
+	source := a.hookBundle.AppIO.Argument(info.ArgMode, "")
+	if source == "" {
+		source = SourceNone
+	}
+	sources := options.AsSliceOfStrings("sources")
+	if len(sources) == 0 {
+		sources = []string{SourceNone}
+	}
+	if !slices.Contains(sources, source) {
+		a.hookBundle.AppIO.Write("  commit message source '"+source+"' is not configured", true, io.VERBOSE)
+		return nil
+	}
+
+	msgFile, msg, err := commitMessage(a.hookBundle)
+	if err != nil {
+		return err
+	}
+
+	tpl, err := a.loadTemplate(options)
+	if err != nil {
+		return err
+	}
+	// existing messages are only replaced if the template includes them
+	if !msg.IsEmpty() && !strings.Contains(tpl.Tree.Root.String(), ".Message") {
+		a.hookBundle.AppIO.Write("  commit message not empty and not used by the template", true, io.VERBOSE)
+		return nil
+	}
+	data, err := a.templateData(options)
+	if err != nil {
+		return err
+	}
+	data.Message = strings.TrimSpace(msg.Message())
+	var rendered bytes.Buffer
+	if err = tpl.Execute(&rendered, data); err != nil {
+		return fmt.Errorf("could not render commit message template: %s", err.Error())
+	}
+	prepared := strings.TrimRight(rendered.String(), "\n") + "\n"
+	if comments := strings.TrimRight(commentLines(msg), "\n"); comments != "" {
+		prepared += comments + "\n"
+	}
+	return a.hookBundle.Repo.PrepareCommitMessage(msgFile, types.NewCommitMessage(prepared, msg.CommentChar()))
+}
+
+// commentLines returns the comment lines of a message, everything below the scissors line is kept as well
+func commentLines(msg *types.CommitMessage) string {
+	var comments []string
+	lines := strings.Split(msg.Raw(), "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, msg.CommentChar()) {
+			continue
+		}
+		if strings.Contains(line, "------------------------ >8 ------------------------") {
+			comments = append(comments, lines[i:]...)
+			break
+		}
+		comments = append(comments, line)
+	}
+	return strings.Join(comments, "\n")
+}
+
+// loadTemplate parses the inline template or the template file
+// Relative template file paths are relative to the repository root.
+func (a *PrepareFromTemplate) loadTemplate(options *configuration.Options) (*template.Template, error) {
+	content := options.AsString("template", "")
+	if file := options.AsString("file", ""); file != "" {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(a.hookBundle.Repo.Path(), file)
+		}
+		data, err := io.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read template file '%s'", file)
+		}
+		content = string(data)
+	}
+	if content == "" {
+		return nil, errors.New("option 'template' or 'file' is missing")
+	}
+	tpl, err := template.New("message").Funcs(templateFunctions).Option("missingkey=zero").Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit message template: %s", err.Error())
+	}
+	return tpl, nil
+}
+
+func (a *PrepareFromTemplate) templateData(options *configuration.Options) (*TemplateData, error) {
+	branchRegex := options.AsString("branch-regex", DefaultBranchRegex)
+	r, err := regexp.Compile(branchRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex '%s': %s", branchRegex, err.Error())
+	}
+	branch := a.hookBundle.Repo.BranchName()
+	data := &TemplateData{
+		Branch: branch,
+		Match:  BranchMatch(r, branch),
+		Author: a.hookBundle.Repo.ConfigValue("user.name", ""),
+		Email:  a.hookBundle.Repo.ConfigValue("user.email", ""),
+	}
+
+	diff, err := a.hookBundle.Repo.StagedDiff()
+	if err != nil {
+		return nil, err
+	}
+	data.Files = diff.Paths()
+	data.Stats.Files = len(diff.Files)
+	for _, file := range diff.Files {
+		data.Stats.Added += len(file.AddedLines())
+		data.Stats.Removed += len(file.RemovedLines())
+	}
+	data.Scopes = Scopes(data.Files)
+	return data, nil
+}
+
+// BranchMatch returns the values of all named groups matching the branch name
+// Every named group is part of the map, groups that did not match are empty.
+func BranchMatch(r *regexp.Regexp, branch string) map[string]string {
+	match := map[string]string{}
+	values := r.FindStringSubmatch(branch)
+	for i, name := range r.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		match[name] = ""
+		if values != nil {
+			match[name] = values[i]
+		}
+	}
+	return match
+}
+
+// Scopes returns the sorted list of top level directories of the given files
+func Scopes(files []string) []string {
+	var scopes []string
+	for _, file := range files {
+		parts := strings.SplitN(filepath.ToSlash(file), "/", 2)
+		if len(parts) < 2 || slices.Contains(scopes, parts[0]) {
+			continue
+		}
+		scopes = append(scopes, parts[0])
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+func NewPrepareFromTemplate(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := PrepareFromTemplate{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrepareCommitMsg}),
+	}
+	return &a
+}
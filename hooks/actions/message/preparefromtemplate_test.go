@@ -0,0 +1,100 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/test"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestBranchMatch(t *testing.T) {
+	r := regexp.MustCompile(DefaultBranchRegex)
+
+	tests := []struct {
+		branch string
+		typ    string
+		key    string
+		slug   string
+	}{
+		{"feature/ABC-123-add-login", "feature", "ABC-123", "add-login"},
+		{"ABC-123-add-login", "", "ABC-123", "add-login"},
+		{"bugfix/broken-login", "bugfix", "", "broken-login"},
+		{"main", "", "", "main"},
+	}
+	for _, test := range tests {
+		match := BranchMatch(r, test.branch)
+		if match["type"] != test.typ || match["key"] != test.key || match["slug"] != test.slug {
+			t.Errorf("Wrong match for '%s', got: %v", test.branch, match)
+		}
+	}
+}
+
+func TestBranchMatchNoMatch(t *testing.T) {
+	match := BranchMatch(regexp.MustCompile(`^(?P<key>[A-Z]+-[0-9]+)$`), "main")
+	if value, ok := match["key"]; !ok || value != "" {
+		t.Errorf("Unmatched groups should be empty, got: %v", match)
+	}
+}
+
+func TestScopes(t *testing.T) {
+	scopes := Scopes([]string{"web/index.html", "api/user.go", "README.md", "api/token.go"})
+	if !slices.Equal(scopes, []string{"api", "web"}) {
+		t.Errorf("Wrong scopes, got: %v", scopes)
+	}
+}
+
+func TestCommentLines(t *testing.T) {
+	msg := types.NewCommitMessage("Add foo\n\n# Please enter the commit message\n# ------------------------ >8 ------------------------\ndiff --git a/foo b/foo", "#")
+
+	expected := "# Please enter the commit message\n# ------------------------ >8 ------------------------\ndiff --git a/foo b/foo"
+	if comments := commentLines(msg); comments != expected {
+		t.Errorf("Wrong comment lines, got: %q", comments)
+	}
+}
+
+func TestPrepareFromTemplateSources(t *testing.T) {
+	allSources := []interface{}{"none", "message", "template", "merge", "squash", "commit"}
+	tests := []struct {
+		source   string
+		message  string
+		template string
+		sources  []interface{}
+		expected string
+	}{
+		{"", "", "{{.Match.type}}: {{humanize .Match.slug}}", nil, "feature: add login"},
+		{"message", "Add form", "{{.Match.type}}: {{humanize .Match.slug}}", nil, ""},
+		{"message", "Add form", "{{.Match.type}}: {{humanize .Match.slug}}", allSources, ""},
+		{"message", "Add form", "{{.Match.type}}: {{ .Message }}", allSources, "feature: Add form"},
+		{"template", "Subject\n\nTemplate body", "{{.Match.type}}: {{humanize .Match.slug}}", allSources, ""},
+		{"template", "", "{{.Match.type}}: {{humanize .Match.slug}}", allSources, "feature: add login"},
+		{"merge", "Merge branch 'main'", "{{.Match.type}}: {{humanize .Match.slug}}", allSources, ""},
+		{"squash", "Squashed commit", "{{.Match.type}}: {{humanize .Match.slug}}", allSources, ""},
+		{"commit", "Amended message", "{{.Match.type}}: {{humanize .Match.slug}}", allSources, ""},
+	}
+	for _, tc := range tests {
+		inOut := test.CreateFakeIO()
+		inOut.SetArguments(map[string]string{info.ArgCommitMsgFile: "msg", info.ArgMode: tc.source})
+		repo := test.CreateFakeRepo().SetBranch("feature/ABC-1-add-login").SetCommitMessage(tc.message + "\n# comment\n")
+		a := NewPrepareFromTemplate(inOut, test.CreateFakeConfig(), repo).(*PrepareFromTemplate)
+
+		options := map[string]interface{}{"template": tc.template}
+		if tc.sources != nil {
+			options["sources"] = tc.sources
+		}
+		if err := a.prepare(configuration.NewOptions(options)); err != nil {
+			t.Fatalf("Unexpected error for source '%s': %s", tc.source, err.Error())
+		}
+		if tc.expected == "" {
+			if repo.Prepared != nil {
+				t.Errorf("Message for source '%s' should be kept, got: %q", tc.source, repo.Prepared.Raw())
+			}
+			continue
+		}
+		if repo.Prepared == nil || repo.Prepared.Raw() != tc.expected+"\n# comment\n" {
+			t.Errorf("Wrong message for source '%s', got: %v", tc.source, repo.Prepared)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package message
+
+import (
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+)
+
+// RequireTrailers blocks commits if the commit message is missing any of the configured trailers.
+// With 'dco' enabled the message must contain a 'Signed-off-by' trailer matching the configured
+// git 'user.name' and 'user.email' to comply with the Developer Certificate of Origin.
+// In 'pre-push' hooks the messages of all pushed commits are checked against their authors.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.RequireTrailers",
+//	  "options": {
+//	    "trailers": ["Refs", "Reviewed-by"],
+//	    "dco": true
+//	  }
+//	}
+type RequireTrailers struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *RequireTrailers) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *RequireTrailers) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking commit message trailers", true, io.VERBOSE)
+
+	return checkCommits(a.hookBundle, func(c *commitToCheck) []string {
+		var problems []string
+		for _, key := range action.Options().AsSliceOfStrings("trailers") {
+			if len(c.msg.TrailerValues(key)) == 0 {
+				problems = append(problems, fmt.Sprintf("trailer '%s' is missing", key))
+			}
+		}
+		if action.Options().AsBool("dco", false) && !c.msg.HasTrailer("Signed-off-by", c.author) {
+			problems = append(
+				problems,
+				fmt.Sprintf("trailer 'Signed-off-by: %s' is missing, use 'git commit --signoff'", c.author),
+			)
+		}
+		return problems
+	})
+}
+
+// gitIdentity returns the configured git user as 'Name <email>'
+func gitIdentity(repo git.Repo) string {
+	return repo.ConfigValue("user.name", "") + " <" + repo.ConfigValue("user.email", "") + ">"
+}
+
+func NewRequireTrailers(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := RequireTrailers{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
+	}
+	return &a
+}
@@ -0,0 +1,113 @@
+package message
+
+import (
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"regexp"
+	"sort"
+)
+
+var (
+	ruleCreationConfig = map[string]func(options *configuration.Options) Rule{
+		"body-line-length": func(options *configuration.Options) Rule {
+			return NewLimitBodyLineLength(options.AsInt("value", options.AsInt("length", 72)))
+		},
+		"capitalize-subject": func(options *configuration.Options) Rule {
+			return NewCapitalizeSubject()
+		},
+		"imperative": func(options *configuration.Options) Rule {
+			return NewUseImperativeMood(options.AsBool("beginning-only", false))
+		},
+		"msg-not-empty": func(options *configuration.Options) Rule {
+			return NewMsgNotEmpty()
+		},
+		"no-period-on-subject-end": func(options *configuration.Options) Rule {
+			return NewNoPeriodOnSubjectEnd()
+		},
+		"separate-subject-from-body": func(options *configuration.Options) Rule {
+			return NewSeparateSubjectFromBodyWithBlankLine()
+		},
+		"subject-length": func(options *configuration.Options) Rule {
+			return NewLimitSubjectLineLength(options.AsInt("value", options.AsInt("length", 50)))
+		},
+	}
+)
+
+// NewRulebookFromOptions creates a Rulebook containing all rules configured in the action options
+// Every option key is the name of a rule, the option value configures the rule.
+//   - false disables the rule
+//   - true activates the rule with its default settings
+//   - a number sets the length for the length rules
+//   - an object sets rule specific settings like {"beginning-only": true}
+//
+// The special option "custom" is a list of regex rules.
+//
+//	{"regex": "^(feat|fix): ", "in": "subject", "match": true, "hint": "use a type prefix"}
+func NewRulebookFromOptions(options *configuration.Options) (*Rulebook, error) {
+	rulebook := NewRulebook()
+
+	// sort the rule names to always report problems in the same order
+	var names []string
+	for name := range options.All() {
+		if name != "custom" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		creationFunc, ok := ruleCreationConfig[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule '%s'", name)
+		}
+		ruleOptions, enabled := toRuleOptions(options.All()[name])
+		if enabled {
+			rulebook.AddRule(creationFunc(ruleOptions))
+		}
+	}
+
+	customRules, err := createCustomRules(options)
+	if err != nil {
+		return nil, err
+	}
+	rulebook.AddRule(customRules...)
+	return rulebook, nil
+}
+
+// toRuleOptions converts a rule configuration value to rule Options
+func toRuleOptions(value interface{}) (*configuration.Options, bool) {
+	switch v := value.(type) {
+	case bool:
+		return configuration.NewOptions(map[string]interface{}{}), v
+	case map[string]interface{}:
+		return configuration.NewOptions(v), true
+	default:
+		return configuration.NewOptions(map[string]interface{}{"value": v}), true
+	}
+}
+
+func createCustomRules(options *configuration.Options) ([]Rule, error) {
+	var rules []Rule
+	custom, ok := options.All()["custom"].([]interface{})
+	if !ok {
+		return rules, nil
+	}
+	for _, item := range custom {
+		settings, isMap := item.(map[string]interface{})
+		if !isMap {
+			return nil, fmt.Errorf("invalid custom rule configuration")
+		}
+		ruleOptions := configuration.NewOptions(settings)
+		regex, err := regexp.Compile(ruleOptions.AsString("regex", ""))
+		if err != nil || ruleOptions.AsString("regex", "") == "" {
+			return nil, fmt.Errorf("invalid custom rule regex '%s'", ruleOptions.AsString("regex", ""))
+		}
+		rules = append(rules, NewRegexRule(
+			regex,
+			ruleOptions.AsString("in", "message"),
+			ruleOptions.AsBool("match", true),
+			ruleOptions.AsString("hint", ""),
+		))
+	}
+	return rules, nil
+}
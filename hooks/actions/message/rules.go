@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/captainhook-go/captainhook/git/types"
 	"github.com/captainhook-go/captainhook/io"
+	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -116,6 +118,13 @@ func (r *UseImperativeMood) IsFollowedBy(msg *types.CommitMessage) (bool, string
 		"uploaded",
 	}
 	subject := msg.Subject()
+	if r.checkBeginningOnly {
+		words := strings.Fields(subject)
+		if len(words) > 0 && slices.Contains(blacklist, strings.ToLower(words[0])) {
+			return false, fmt.Sprintf("%ssubject should not start with '%s'", hint, words[0])
+		}
+		return true, ""
+	}
 	for _, word := range blacklist {
 		if strings.Contains(subject, word) {
 			return false, fmt.Sprintf("%ssubject should not contain '%s'", hint, word)
@@ -127,3 +136,41 @@ func (r *UseImperativeMood) IsFollowedBy(msg *types.CommitMessage) (bool, string
 func NewUseImperativeMood(beginningOnly bool) *UseImperativeMood {
 	return &UseImperativeMood{checkBeginningOnly: beginningOnly}
 }
+
+// RegexRule checks the subject, the body or the whole message against a custom regex
+// If mustMatch is false the rule is violated if the regex matches.
+type RegexRule struct {
+	regex     *regexp.Regexp
+	in        string
+	mustMatch bool
+	hint      string
+}
+
+func (r *RegexRule) IsFollowedBy(msg *types.CommitMessage) (bool, string) {
+	var text string
+	switch r.in {
+	case "subject":
+		text = msg.Subject()
+	case "body":
+		text = msg.Body()
+	default:
+		text = msg.Message()
+	}
+	if r.regex.MatchString(text) == r.mustMatch {
+		return true, ""
+	}
+	return false, r.hint
+}
+
+func NewRegexRule(regex *regexp.Regexp, in string, mustMatch bool, hint string) *RegexRule {
+	if in == "" {
+		in = "message"
+	}
+	if hint == "" {
+		hint = fmt.Sprintf("%s has to match '%s'", in, regex.String())
+		if !mustMatch {
+			hint = fmt.Sprintf("%s must not match '%s'", in, regex.String())
+		}
+	}
+	return &RegexRule{regex: regex, in: in, mustMatch: mustMatch, hint: hint}
+}
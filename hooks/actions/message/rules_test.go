@@ -0,0 +1,50 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git/types"
+	"testing"
+)
+
+func TestUseImperativeMoodBeginningOnly(t *testing.T) {
+	rule := NewUseImperativeMood(true)
+
+	ok, _ := rule.IsFollowedBy(types.NewCommitMessage("Add fixed tests", "#"))
+	if !ok {
+		t.Errorf("Only the first word should be checked")
+	}
+	ok, _ = rule.IsFollowedBy(types.NewCommitMessage("Fixed tests", "#"))
+	if ok {
+		t.Errorf("Subject starting with 'fixed' should fail")
+	}
+}
+
+func TestNewRulebookFromOptionsUnknownRule(t *testing.T) {
+	_, err := NewRulebookFromOptions(configuration.NewOptions(map[string]interface{}{"foo": true}))
+	if err == nil {
+		t.Errorf("Unknown rules should cause an error")
+	}
+}
+
+func TestNewRulebookFromOptions(t *testing.T) {
+	options := configuration.NewOptions(map[string]interface{}{
+		"subject-length":     10.0,
+		"capitalize-subject": false,
+		"custom": []interface{}{
+			map[string]interface{}{"regex": "^[A-Z]+-[0-9]+ ", "in": "subject", "hint": "issue key missing"},
+		},
+	})
+	rulebook, err := NewRulebookFromOptions(options)
+	if err != nil {
+		t.Fatalf("No error expected, got: %s", err.Error())
+	}
+
+	ok, _ := rulebook.IsFollowedBy(types.NewCommitMessage("AB-12 foo", "#"))
+	if !ok {
+		t.Errorf("Message should follow all rules")
+	}
+	ok, problems := rulebook.IsFollowedBy(types.NewCommitMessage("lowercase and way too long", "#"))
+	if ok || len(problems) != 2 {
+		t.Errorf("Expected 2 problems, got: %v", problems)
+	}
+}
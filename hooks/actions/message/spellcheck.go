@@ -0,0 +1,100 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultProjectDictionary = ".captainhook/words.txt"
+)
+
+// SpellCheck blocks commits if the commit message contains unknown words.
+// Words are checked against an embedded English word list and the project dictionaries.
+// Project dictionaries contain one word per line, by default '.captainhook/words.txt' is used.
+// Code spans, urls, issue keys, paths, identifiers and trailers are not checked.
+// In 'warn' mode unknown words are only reported and the commit is not blocked.
+// In 'pre-push' hooks the messages of all pushed commits are checked.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Message.SpellCheck",
+//	  "options": {
+//	    "mode": "warn",
+//	    "dictionaries": [".captainhook/words.txt"],
+//	    "words": ["captainhook"],
+//	    "check-body": true
+//	  }
+//	}
+type SpellCheck struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *SpellCheck) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *SpellCheck) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking commit message spelling", true, io.VERBOSE)
+
+	checker, err := a.setupSpellChecker(action.Options())
+	if err != nil {
+		return err
+	}
+	checkBody := action.Options().AsBool("check-body", true)
+
+	err = checkCommits(a.hookBundle, func(c *commitToCheck) []string {
+		lines := []string{c.msg.Subject()}
+		if checkBody {
+			lines = c.msg.LinesWithoutTrailers()
+		}
+		var problems []string
+		for _, line := range lines {
+			// skip indented code blocks and quotes
+			if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, ">") {
+				continue
+			}
+			for _, misspelling := range checker.Check(line) {
+				problems = append(problems, misspelling.String())
+			}
+		}
+		return problems
+	})
+	if err != nil && action.Options().AsString("mode", "block") == "warn" {
+		a.hookBundle.AppIO.Write("<comment>"+err.Error()+"</comment>", true, io.NORMAL)
+		return nil
+	}
+	return err
+}
+
+func (a *SpellCheck) setupSpellChecker(options *configuration.Options) (*SpellChecker, error) {
+	checker := NewSpellChecker()
+	checker.AddWords(options.AsSliceOfStrings("words")...)
+
+	files := options.AsSliceOfStrings("dictionaries")
+	if len(files) == 0 {
+		files = []string{DefaultProjectDictionary}
+	}
+	for _, file := range files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(a.hookBundle.Repo.Path(), file)
+		}
+		if err := checker.AddDictionary(file); err != nil {
+			return nil, err
+		}
+	}
+	return checker, nil
+}
+
+func NewSpellCheck(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := SpellCheck{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.CommitMsg, info.PrePush}),
+	}
+	return &a
+}
@@ -0,0 +1,222 @@
+package message
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//go:embed dictionary/*.txt
+var dictionaries embed.FS
+
+var (
+	// spellIgnore matches everything that should not be spell checked
+	//   - code spans `foo()`
+	//   - urls and email addresses
+	//   - issue keys like ABC-123 and #123
+	//   - paths and file names like src/main.go
+	spellIgnore = regexp.MustCompile(
+		"`[^`]*`" +
+			`|[a-zA-Z][a-zA-Z0-9+.\-]*://\S+|www\.\S+|\S+@\S+\.\S+` +
+			`|\b[A-Z][A-Z0-9]*-[0-9]+\b|#[0-9]+` +
+			`|\S*[/\\]\S*|\S+\.[a-zA-Z0-9]+\b`,
+	)
+	// spellToken matches words and identifiers
+	spellToken = regexp.MustCompile(`[\p{L}\p{N}_]+(?:'[\p{L}]+)*`)
+	// contractionSuffixes are the endings of possessives and contractions like "user's", "they're" or "isn't"
+	contractionSuffixes = []string{"'s", "'re", "'ve", "'ll", "'d", "'m", "n't"}
+	// irregularContractions can not be derived from a known word by removing the suffix
+	irregularContractions = map[string]bool{"can't": true, "won't": true, "shan't": true, "ain't": true}
+)
+
+// Misspelling is an unknown word with suggestions for the correct spelling
+type Misspelling struct {
+	Word        string
+	Suggestions []string
+}
+
+func (m *Misspelling) String() string {
+	if len(m.Suggestions) == 0 {
+		return "unknown word '" + m.Word + "'"
+	}
+	return "unknown word '" + m.Word + "', did you mean " + strings.Join(m.Suggestions, ", ") + "?"
+}
+
+// SpellChecker checks texts against a list of known words
+// Words are compared case-insensitive.
+type SpellChecker struct {
+	words map[string]bool
+}
+
+// AddWords adds words to the list of known words
+func (s *SpellChecker) AddWords(words ...string) {
+	for _, word := range words {
+		s.words[strings.ToLower(word)] = true
+	}
+}
+
+// AddDictionary adds all words of a dictionary file to the list of known words
+// Every line contains one word, lines starting with '#' are ignored.
+// If the file does not exist nothing is added.
+func (s *SpellChecker) AddDictionary(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("could not read dictionary '%s': %s", path, err.Error())
+	}
+	s.addDictionaryData(string(data))
+	return nil
+}
+
+func (s *SpellChecker) addDictionaryData(data string) {
+	for _, line := range io.SplitLines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s.AddWords(line)
+	}
+}
+
+// IsKnown tells you if a word is in the list of known words
+func (s *SpellChecker) IsKnown(word string) bool {
+	word = strings.ToLower(word)
+	if s.words[word] || irregularContractions[word] {
+		return true
+	}
+	for _, suffix := range contractionSuffixes {
+		if strings.HasSuffix(word, suffix) && s.words[strings.TrimSuffix(word, suffix)] {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns all unknown words of a text
+// Every unknown word is only reported once.
+func (s *SpellChecker) Check(text string) []*Misspelling {
+	var misspellings []*Misspelling
+	reported := map[string]bool{}
+	for _, word := range Tokenize(text) {
+		if s.IsKnown(word) || reported[strings.ToLower(word)] {
+			continue
+		}
+		reported[strings.ToLower(word)] = true
+		misspellings = append(misspellings, &Misspelling{Word: word, Suggestions: s.Suggest(word, 3)})
+	}
+	return misspellings
+}
+
+// Suggest returns up to max known words with an edit distance of 2 or less
+// The suggestions are sorted by edit distance.
+func (s *SpellChecker) Suggest(word string, max int) []string {
+	word = strings.ToLower(word)
+	type candidate struct {
+		word     string
+		distance int
+	}
+	var candidates []candidate
+	for known := range s.words {
+		if diff := len(known) - len(word); diff > 2 || diff < -2 {
+			continue
+		}
+		if distance := EditDistance(word, known); distance <= 2 {
+			candidates = append(candidates, candidate{known, distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance == candidates[j].distance {
+			return candidates[i].word < candidates[j].word
+		}
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < max; i++ {
+		suggestions = append(suggestions, candidates[i].word)
+	}
+	return suggestions
+}
+
+// Tokenize returns all words of a text that should be spell checked
+// Code spans, urls, issue keys, paths and identifiers like 'camelCase', 'snake_case',
+// 'CONSTANTS' or words containing digits are skipped.
+func Tokenize(text string) []string {
+	text = spellIgnore.ReplaceAllString(strings.ReplaceAll(text, "’", "'"), " ")
+	var words []string
+	for _, token := range spellToken.FindAllString(text, -1) {
+		if isIdentifier(token) {
+			continue
+		}
+		words = append(words, token)
+	}
+	return words
+}
+
+// isIdentifier detects tokens that are most likely code and not natural language
+func isIdentifier(token string) bool {
+	if len([]rune(token)) < 2 || strings.Contains(token, "_") {
+		return true
+	}
+	upper := 0
+	for i, r := range []rune(token) {
+		if unicode.IsDigit(r) {
+			return true
+		}
+		if unicode.IsUpper(r) {
+			upper++
+			// camelCase or PascalCase
+			if i > 0 && upper == 1 {
+				return true
+			}
+		}
+	}
+	// acronyms and constants
+	return upper > 1
+}
+
+// EditDistance calculates the Damerau-Levenshtein distance of two words
+// Besides insertions, deletions and substitutions, transpositions of two adjacent characters count as one edit.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// NewSpellChecker creates a SpellChecker knowing all words of the embedded dictionaries
+func NewSpellChecker() *SpellChecker {
+	s := &SpellChecker{words: map[string]bool{}}
+	files, _ := dictionaries.ReadDir("dictionary")
+	for _, file := range files {
+		data, _ := dictionaries.ReadFile("dictionary/" + file.Name())
+		s.addDictionaryData(string(data))
+	}
+	return s
+}
@@ -0,0 +1,58 @@
+package message
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTokenizeSkipsCode(t *testing.T) {
+	words := Tokenize("Fix `fooBar()` in src/main.go for ABC-123 and #42 see https://example.com userID MAX_SIZE v2 iPhone's")
+	expected := []string{"Fix", "in", "for", "and", "see"}
+	if !slices.Equal(words, expected) {
+		t.Errorf("Wrong tokens, expected %v got %v", expected, words)
+	}
+}
+
+func TestSpellCheckerCheck(t *testing.T) {
+	checker := NewSpellChecker()
+	checker.AddWords("captainhook")
+
+	misspellings := checker.Check("Add recieve method to the captainhook user's config")
+	if len(misspellings) != 1 || misspellings[0].Word != "recieve" {
+		t.Fatalf("Only 'recieve' should be unknown, got: %v", misspellings)
+	}
+	if !slices.Contains(misspellings[0].Suggestions, "receive") {
+		t.Errorf("'receive' should be suggested, got: %v", misspellings[0].Suggestions)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{"commit", "commit", 0},
+		{"comit", "commit", 1},
+		{"comimt", "commit", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, test := range tests {
+		if d := EditDistance(test.a, test.b); d != test.distance {
+			t.Errorf("Distance of '%s' and '%s' should be %d, got %d", test.a, test.b, test.distance, d)
+		}
+	}
+}
+
+func TestSpellCheckerKnowsContractions(t *testing.T) {
+	checker := NewSpellChecker()
+
+	for _, text := range []string{
+		"Don't crash when the user's token expired",
+		"It doesn't work, it isn't ready and we can't wait",
+		"They’re sure we'll need it",
+	} {
+		if misspellings := checker.Check(text); len(misspellings) != 0 {
+			t.Errorf("Contractions should be known in '%s', got: %v", text, misspellings)
+		}
+	}
+}
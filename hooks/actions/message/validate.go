@@ -0,0 +1,105 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// commitToCheck is a commit message to validate
+// In 'commit-msg' hooks the hash is empty and the author is the configured git user.
+type commitToCheck struct {
+	hash   string
+	author string
+	msg    *types.CommitMessage
+}
+
+// validateCommitMessage checks all commit messages against all rules of a Rulebook
+func validateCommitMessage(bundle *hooks.HookBundle, rulebook *Rulebook) error {
+	return checkCommits(bundle, func(c *commitToCheck) []string {
+		_, problems := rulebook.IsFollowedBy(c.msg)
+		return problems
+	})
+}
+
+// checkCommits runs a check for every commit message and reports all problems
+// In 'commit-msg' hooks the message of the current commit is checked, in 'pre-push' hooks
+// the messages of all pushed commits are checked.
+func checkCommits(bundle *hooks.HookBundle, check func(c *commitToCheck) []string) error {
+	commits, err := commitsToCheck(bundle)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, c := range commits {
+		problems := check(c)
+		if len(problems) == 0 {
+			continue
+		}
+		failed++
+		if c.hash == "" {
+			for _, problem := range problems {
+				bundle.AppIO.Write(problem, true, io.NORMAL)
+			}
+			outputMessage(bundle.AppIO, c.msg)
+			continue
+		}
+		bundle.AppIO.Write("<info>"+c.hash+"</info> "+c.msg.Subject(), true, io.NORMAL)
+		for _, problem := range problems {
+			bundle.AppIO.Write("  - "+problem, true, io.NORMAL)
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	if bundle.AppIO.Argument(info.ArgCommand, "") == info.PrePush {
+		return fmt.Errorf("%d commit message(s) did not follow all rules", failed)
+	}
+	return errors.New("commit message did not follow all rules")
+}
+
+// commitsToCheck returns the current commit message or the messages of all pushed commits
+func commitsToCheck(bundle *hooks.HookBundle) ([]*commitToCheck, error) {
+	if bundle.AppIO.Argument(info.ArgCommand, "") == info.PrePush {
+		var commits []*commitToCheck
+		for _, commit := range input.PushedCommits(bundle.AppIO, bundle.Repo) {
+			commits = append(commits, &commitToCheck{
+				hash:   commit.Hash,
+				author: commit.Author + " <" + commit.Email + ">",
+				msg:    types.NewCommitMessage(commit.Subject+"\n\n"+strings.Trim(commit.Body, "\n"), types.NoCommentChar),
+			})
+		}
+		return commits, nil
+	}
+
+	_, msg, err := commitMessage(bundle)
+	if err != nil {
+		return nil, err
+	}
+	return []*commitToCheck{{author: gitIdentity(bundle.Repo), msg: msg}}, nil
+}
+
+// commitMessage reads the commit message from the file passed to the hook
+// The path of the file is returned as well, so the message can be written back.
+func commitMessage(bundle *hooks.HookBundle) (string, *types.CommitMessage, error) {
+	commitMessageFile := bundle.AppIO.Argument(info.ArgCommitMsgFile, "")
+	if commitMessageFile == "" {
+		return "", nil, errors.New("commit message file argument is missing")
+	}
+	msg, err := bundle.Repo.CommitMessage(commitMessageFile)
+	if err != nil {
+		return "", nil, err
+	}
+	return commitMessageFile, msg, nil
+}
+
+func outputMessage(appIO io.IO, msg *types.CommitMessage) {
+	appIO.Write("===========================[ commit message ]===========================", true, io.NORMAL)
+	appIO.Write(msg.Message(), true, io.NORMAL)
+	appIO.Write(strings.Repeat("=", 72), true, io.NORMAL)
+}
@@ -0,0 +1,68 @@
+package message
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestCheckCommitsChecksEveryPushedCommit(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876"})
+	repo := test.CreateFakeRepo()
+	repo.SetLog([]*types.Commit{
+		{Hash: "abc1234", Subject: "ABC-1 Add foo"},
+		{Hash: "def5678", Subject: "Add bar"},
+	})
+	bundle := hooks.NewHookBundle(inOut, test.CreateFakeConfig(), repo, []string{info.PrePush})
+
+	err := checkCommits(bundle, func(c *commitToCheck) []string {
+		if !strings.HasPrefix(c.msg.Subject(), "ABC-") {
+			return []string{"issue key missing"}
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("Commit without issue key should fail")
+	}
+	if len(inOut.Out) != 2 || inOut.Out[0] != "<info>def5678</info> Add bar" || inOut.Out[1] != "  - issue key missing" {
+		t.Errorf("Only the failing commit should be reported, got: %v", inOut.Out)
+	}
+}
+
+func TestCommitsToCheckIncludesNewBranches(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/feature 12345 refs/heads/feature 0000000000000000000000000000000000000000"})
+	repo := test.CreateFakeRepo()
+	repo.SetLog([]*types.Commit{{Hash: "abc1234", Subject: "Add foo"}})
+	bundle := hooks.NewHookBundle(inOut, test.CreateFakeConfig(), repo, []string{info.PrePush})
+
+	commits, err := commitsToCheck(bundle)
+	if err != nil || len(commits) != 1 {
+		t.Errorf("Commits of new branches should be checked, got: %d", len(commits))
+	}
+}
+
+func TestCommitsToCheckKeepsAllLinesOfPushedMessages(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876"})
+	repo := test.CreateFakeRepo()
+	repo.SetLog([]*types.Commit{{
+		Hash:    "abc1234",
+		Subject: "Fix the parser",
+		Body:    "#123 was caused by tabs\n# ------------------------ >8 ------------------------\nSigned-off-by: Jane <jane@example.com>\n",
+	}})
+	bundle := hooks.NewHookBundle(inOut, test.CreateFakeConfig(), repo, []string{info.PrePush})
+
+	commits, _ := commitsToCheck(bundle)
+	expected := "#123 was caused by tabs\n# ------------------------ >8 ------------------------\nSigned-off-by: Jane <jane@example.com>"
+	if len(commits) != 1 || commits[0].msg.Body() != expected {
+		t.Errorf("Pushed messages should not lose any lines, got: %q", commits[0].msg.Body())
+	}
+}
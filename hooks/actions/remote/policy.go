@@ -0,0 +1,144 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Policy prevents you from pushing to remotes you are not supposed to push to.
+// Remote urls matching one of the 'allow' regexes are always allowed. Urls matching one of the 'deny'
+// regexes are blocked. If 'allow' regexes are configured all other urls are blocked as well.
+// With 'branches' you can restrict which branches can be pushed to which remotes. Remotes are
+// referenced by name or url.
+// If 'confirm' is active and you are in a terminal you are asked if you want to push anyway.
+// Only applicable for 'pre-push' hooks.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Remote.Policy",
+//	  "options": {
+//	    "allow": ["github\\.com[:/]my-company/"],
+//	    "deny": ["github\\.com", "gitlab\\.com"],
+//	    "branches": {"release/*": ["origin"]},
+//	    "confirm": true
+//	  }
+//	}
+type Policy struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *Policy) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *Policy) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking remote policy", true, io.VERBOSE)
+
+	problems, err := a.check(action.Options())
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	if action.Options().AsBool("confirm", false) && a.hookBundle.AppIO.IsInteractive() && a.confirm(problems) {
+		return nil
+	}
+	return errors.New("push blocked by remote policy:\n  - " + strings.Join(problems, "\n  - "))
+}
+
+// confirm asks if the push should happen anyway
+// The problems are part of the question because the action output is only shown after the action finished.
+func (a *Policy) confirm(problems []string) bool {
+	answer := a.hookBundle.AppIO.Ask(
+		"<comment>  - "+strings.Join(problems, "\n  - ")+"</comment>\nPush anyway? <comment>[y,N]</comment> ",
+		"n",
+	)
+	return strings.ToLower(answer) == "y"
+}
+
+func (a *Policy) check(options *configuration.Options) ([]string, error) {
+	target := a.hookBundle.AppIO.Argument(info.ArgTarget, "")
+	url := a.hookBundle.AppIO.Argument(info.ArgURL, target)
+
+	var problems []string
+	allowed, err := a.isAllowed(url, options.AsSliceOfStrings("allow"), options.AsSliceOfStrings("deny"))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		problems = append(problems, "pushing to '"+url+"' is not allowed")
+	}
+
+	branches, ok := options.All()["branches"].(map[string]interface{})
+	if !ok {
+		return problems, nil
+	}
+	var patterns []string
+	for pattern := range branches {
+		patterns = append(patterns, pattern)
+	}
+	slices.Sort(patterns)
+
+	for _, ref := range input.PushRefs(a.hookBundle.AppIO) {
+		if !ref.IsBranch() {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, ref.Branch()); !matched {
+				continue
+			}
+			remotes := configuration.NewOptions(branches).AsSliceOfStrings(pattern)
+			if !slices.Contains(remotes, target) && !slices.Contains(remotes, url) {
+				problems = append(problems, fmt.Sprintf(
+					"branch <comment>%s</comment> can only be pushed to '%s'", ref.Branch(), strings.Join(remotes, "', '"),
+				))
+			}
+		}
+	}
+	return problems, nil
+}
+
+// isAllowed checks an url against the allow and deny lists
+func (a *Policy) isAllowed(url string, allow, deny []string) (bool, error) {
+	matchesAllow, err := matchesAny(url, allow)
+	if err != nil || matchesAllow {
+		return matchesAllow, err
+	}
+	matchesDeny, err := matchesAny(url, deny)
+	if err != nil {
+		return false, err
+	}
+	return !matchesDeny && len(allow) == 0, nil
+}
+
+func matchesAny(url string, patterns []string) (bool, error) {
+	for _, pattern := range patterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid url regex '%s': %s", pattern, err.Error())
+		}
+		if r.MatchString(url) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func NewPolicy(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := Policy{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrePush}),
+	}
+	return &a
+}
@@ -0,0 +1,62 @@
+package remote
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestPolicyAllowAndDeny(t *testing.T) {
+	a := NewPolicy(test.CreateFakeIO(), test.CreateFakeConfig(), test.CreateFakeRepo()).(*Policy)
+	allow := []string{"github\\.com[:/]acme/"}
+	deny := []string{"github\\.com"}
+
+	tests := []struct {
+		url     string
+		allow   []string
+		allowed bool
+	}{
+		{"git@github.com:acme/foo.git", allow, true},
+		{"git@github.com:someone/foo.git", allow, false},
+		{"git@git.acme.com:foo.git", allow, false},
+		{"git@git.acme.com:foo.git", nil, true},
+	}
+	for _, test := range tests {
+		allowed, _ := a.isAllowed(test.url, test.allow, deny)
+		if allowed != test.allowed {
+			t.Errorf("Url '%s' should be allowed: %t", test.url, test.allowed)
+		}
+	}
+}
+
+func TestPolicyRestrictsBranchesToRemotes(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"target": "fork", "url": "git@github.com:someone/foo.git"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/release/1.0 12345 refs/heads/release/1.0 09876\n" +
+		"refs/heads/feature/foo 12345 refs/heads/feature/foo 09876\n"})
+	a := NewPolicy(inOut, test.CreateFakeConfig(), test.CreateFakeRepo()).(*Policy)
+
+	problems, err := a.check(configuration.NewOptions(map[string]interface{}{
+		"branches": map[string]interface{}{"release/*": []interface{}{"origin"}},
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "release/1.0") {
+		t.Errorf("Only the release branch should be blocked, got: %v", problems)
+	}
+}
+
+func TestPolicyConfirmShowsProblems(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetAnswers([]string{"y"})
+	a := NewPolicy(inOut, test.CreateFakeConfig(), test.CreateFakeRepo()).(*Policy)
+
+	if !a.confirm([]string{"pushing to 'fork' is not allowed"}) {
+		t.Errorf("Push should be confirmed")
+	}
+	if len(inOut.Out) != 1 || !strings.Contains(inOut.Out[0], "pushing to 'fork' is not allowed") {
+		t.Errorf("Problems should be part of the question, got: %v", inOut.Out)
+	}
+}
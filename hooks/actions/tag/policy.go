@@ -0,0 +1,127 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"github.com/captainhook-go/captainhook/io"
+	"github.com/hashicorp/go-version"
+	"slices"
+	"strings"
+)
+
+// Policy validates the tags you push.
+// Tag names have to be semantic versions like 'v1.2.3', tags have to be annotated and existing
+// tags can not be moved to another commit. With 'require-increase' every new version has to be
+// greater than all existing version tags.
+// Only applicable for 'pre-push' hooks.
+//
+// Example configuration:
+//
+//	{
+//	  "run": "CaptainHook::Tag.Policy",
+//	  "options": {
+//	    "semver": true,
+//	    "require-annotated": true,
+//	    "allow-repoint": false,
+//	    "require-increase": true
+//	  }
+//	}
+type Policy struct {
+	hookBundle *hooks.HookBundle
+}
+
+func (a *Policy) IsApplicableFor(hook string) bool {
+	return a.hookBundle.Restriction.IsApplicableFor(hook)
+}
+
+func (a *Policy) Run(action *configuration.Action) error {
+	a.hookBundle.AppIO.Write("checking tag policy", true, io.VERBOSE)
+	return a.check(action.Options())
+}
+
+func (a *Policy) check(options *configuration.Options) error {
+	var tags []*input.PushRef
+	var pushed []string
+	for _, ref := range input.PushRefs(a.hookBundle.AppIO) {
+		if ref.IsTag() && !ref.IsDeletion() {
+			tags = append(tags, ref)
+			pushed = append(pushed, ref.Tag())
+		}
+	}
+	if len(tags) == 0 {
+		a.hookBundle.AppIO.Write("no tags pushed", true, io.VERBOSE)
+		return nil
+	}
+
+	var latest *version.Version
+	if options.AsBool("require-increase", false) {
+		var err error
+		if latest, err = a.latestVersion(pushed); err != nil {
+			return err
+		}
+	}
+
+	var problems []string
+	for _, ref := range tags {
+		tag := "<comment>" + ref.Tag() + "</comment>"
+
+		if options.AsBool("semver", true) || latest != nil {
+			v, err := version.NewSemver(ref.Tag())
+			if err != nil {
+				problems = append(problems, tag+" is not a semantic version")
+			} else if latest != nil && !v.GreaterThan(latest) {
+				problems = append(problems, fmt.Sprintf("%s has to be greater than the latest version '%s'", tag, latest.Original()))
+			}
+		}
+		if options.AsBool("require-annotated", true) {
+			objectType, err := a.hookBundle.Repo.ObjectType(ref.LocalHash)
+			if err != nil {
+				return err
+			}
+			if objectType != "tag" {
+				problems = append(problems, tag+" is not annotated, use 'git tag -a'")
+			}
+		}
+		if !options.AsBool("allow-repoint", false) && !ref.IsNew() && ref.RemoteHash != ref.LocalHash {
+			problems = append(problems, tag+" already exists and can not be moved")
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("tags did not follow the tag policy:\n  - " + strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// latestVersion returns the greatest version of all existing tags not pushed right now
+func (a *Policy) latestVersion(pushed []string) (*version.Version, error) {
+	tags, err := a.hookBundle.Repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var latest *version.Version
+	for _, tag := range tags {
+		if slices.Contains(pushed, tag) {
+			continue
+		}
+		v, err := version.NewSemver(tag)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+func NewPolicy(appIO io.IO, conf *configuration.Configuration, repo git.Repo) hooks.Action {
+	a := Policy{
+		hookBundle: hooks.NewHookBundle(appIO, conf, repo, []string{info.PrePush}),
+	}
+	return &a
+}
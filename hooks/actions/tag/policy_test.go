@@ -0,0 +1,39 @@
+package tag
+
+import (
+	"github.com/captainhook-go/captainhook/configuration"
+	"github.com/captainhook-go/captainhook/test"
+	"strings"
+	"testing"
+)
+
+func TestPolicy(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetOptions(map[string]string{"input": "refs/tags/v1.2.0 aaa refs/tags/v1.2.0 0000000\n" +
+		"refs/tags/latest bbb refs/tags/latest 0000000\n" +
+		"refs/tags/v1.0.0 ccc refs/tags/v1.0.0 ddd\n" +
+		"refs/heads/main eee refs/heads/main fff\n"})
+	repo := test.CreateFakeRepo().
+		SetObjectTypes(map[string]string{"aaa": "tag", "ccc": "tag"}).
+		SetTags([]string{"v1.0.0", "v1.1.0", "v1.2.0", "latest"})
+	a := NewPolicy(inOut, test.CreateFakeConfig(), repo).(*Policy)
+
+	err := a.check(configuration.NewOptions(map[string]interface{}{"require-increase": true}))
+	if err == nil {
+		t.Fatalf("Tag policy should fail")
+	}
+	expected := []string{
+		"<comment>latest</comment> is not a semantic version",
+		"<comment>latest</comment> is not annotated",
+		"<comment>v1.0.0</comment> has to be greater than the latest version 'v1.1.0'",
+		"<comment>v1.0.0</comment> already exists and can not be moved",
+	}
+	for _, problem := range expected {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("Problem '%s' not reported, got: %s", problem, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "<comment>v1.2.0</comment>") || strings.Contains(err.Error(), "main") {
+		t.Errorf("Valid tags and branches should not be reported, got: %s", err.Error())
+	}
+}
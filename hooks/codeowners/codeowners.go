@@ -0,0 +1,112 @@
+package codeowners
+
+import (
+	"errors"
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/hooks/util"
+	"github.com/captainhook-go/captainhook/io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Locations lists the places a CODEOWNERS file is searched in, the first file found is used
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule assigns owners to all files matching a pattern
+// A rule without owners marks files as not owned by anyone.
+type Rule struct {
+	Pattern string
+	Owners  []string
+}
+
+// Matches checks if a file matches the rules pattern
+// Patterns follow the gitignore syntax, so a pattern matching a directory matches all files inside.
+// Patterns ending with a wildcard like 'docs/*' only match the files directly inside the directory.
+func (r *Rule) Matches(file string) bool {
+	if util.MatchesPattern(file, r.Pattern) {
+		return true
+	}
+	if strings.HasSuffix(r.Pattern, "/") || strings.HasSuffix(r.Pattern, "*") {
+		return false
+	}
+	return util.MatchesPattern(file, r.Pattern+"/")
+}
+
+// CodeOwners holds all rules of a CODEOWNERS file
+type CodeOwners struct {
+	rules []*Rule
+}
+
+// Rules returns all parsed rules in the order they are defined
+func (c *CodeOwners) Rules() []*Rule {
+	return c.rules
+}
+
+// Owners returns the owners of a file
+// Like GitHub the last matching rule wins.
+func (c *CodeOwners) Owners(file string) []string {
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].Matches(file) {
+			return c.rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// OwnersOf returns all files grouped by owner
+func (c *CodeOwners) OwnersOf(files []string) map[string][]string {
+	owned := map[string][]string{}
+	for _, file := range files {
+		for _, owner := range c.Owners(file) {
+			owned[owner] = append(owned[owner], file)
+		}
+	}
+	return owned
+}
+
+// AllOwners returns the sorted list of all owners of the given files
+func (c *CodeOwners) AllOwners(files []string) []string {
+	var owners []string
+	for owner := range c.OwnersOf(files) {
+		owners = append(owners, owner)
+	}
+	slices.Sort(owners)
+	return owners
+}
+
+// Parse creates CodeOwners from the content of a CODEOWNERS file
+func Parse(content string) *CodeOwners {
+	c := &CodeOwners{}
+	for _, line := range io.SplitLines(content) {
+		line = stripComment(line)
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		c.rules = append(c.rules, &Rule{Pattern: strings.ReplaceAll(fields[0], "\\#", "#"), Owners: fields[1:]})
+	}
+	return c
+}
+
+// stripComment removes everything after a '#' that is not escaped like '\#'
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// Load reads the CODEOWNERS file of a repository
+func Load(repo git.Repo) (*CodeOwners, error) {
+	for _, location := range Locations {
+		content, err := os.ReadFile(filepath.Join(repo.Path(), location))
+		if err == nil {
+			return Parse(string(content)), nil
+		}
+	}
+	return nil, errors.New("no CODEOWNERS file found in " + strings.Join(Locations, ", "))
+}
@@ -0,0 +1,83 @@
+package codeowners
+
+import (
+	"slices"
+	"testing"
+)
+
+const content = `# default owners
+*       @acme/core
+
+*.js    @acme/frontend # inline comment
+/docs/  @acme/docs docs@acme.com
+apps/   @octocat
+/build/logs/
+\#notes.md @acme/notes
+`
+
+func TestOwners(t *testing.T) {
+	c := Parse(content)
+
+	tests := []struct {
+		file   string
+		owners []string
+	}{
+		{"main.go", []string{"@acme/core"}},
+		{"web/app.js", []string{"@acme/frontend"}},
+		{"docs/index.md", []string{"@acme/docs", "docs@acme.com"}},
+		{"src/docs/index.md", []string{"@acme/core"}},
+		{"src/apps/foo.go", []string{"@octocat"}},
+		{"build/logs/out.log", nil},
+		{"#notes.md", []string{"@acme/notes"}},
+	}
+	for _, test := range tests {
+		if owners := c.Owners(test.file); !slices.Equal(owners, test.owners) {
+			t.Errorf("Wrong owners for '%s', got: %v, want: %v", test.file, owners, test.owners)
+		}
+	}
+}
+
+// TestRuleMatchesGitHubExamples checks the patterns of the example CODEOWNERS file in the GitHub docs
+// https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+func TestRuleMatchesGitHubExamples(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		matches bool
+	}{
+		{"*", "foo/bar.go", true},
+		{"*.js", "web/app.js", true},
+		{"*.go", "web/app.js", false},
+		{"/build/logs/", "build/logs/out.log", true},
+		{"/build/logs/", "build/logs/2024/out.log", true},
+		{"/build/logs/", "src/build/logs/out.log", false},
+		{"docs/*", "docs/getting-started.md", true},
+		{"docs/*", "docs/build-app/troubleshooting.md", false},
+		{"apps/", "apps/foo.go", true},
+		{"apps/", "src/apps/foo.go", true},
+		{"/docs/", "docs/index.md", true},
+		{"/docs/", "src/docs/index.md", false},
+		{"/scripts/", "scripts/build.sh", true},
+		{"**/logs", "build/logs/out.log", true},
+		{"**/logs", "deeply/nested/logs/out.log", true},
+		{"/apps/github", "apps/github", true},
+		{"/apps/github", "apps/github/index.js", true},
+		{"/apps/github", "apps/gitlab/index.js", false},
+	}
+	for _, test := range tests {
+		rule := &Rule{Pattern: test.pattern}
+		if rule.Matches(test.file) != test.matches {
+			t.Errorf("Pattern '%s' should match '%s': %t", test.pattern, test.file, test.matches)
+		}
+	}
+}
+
+func TestAllOwners(t *testing.T) {
+	c := Parse(content)
+
+	owners := c.AllOwners([]string{"main.go", "web/app.js", "docs/index.md"})
+	expected := []string{"@acme/core", "@acme/docs", "@acme/frontend", "docs@acme.com"}
+	if !slices.Equal(owners, expected) {
+		t.Errorf("Wrong owners, got: %v", owners)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/captainhook-go/captainhook/git"
 	"github.com/captainhook-go/captainhook/info"
 	"github.com/captainhook-go/captainhook/io"
+	"slices"
 )
 
 // StagedOrChangedFiles will return a list of files
@@ -18,6 +19,94 @@ func StagedOrChangedFiles(appIO io.IO, repo git.Repo) ([]string, error) {
 	return ChangedFiles(appIO, repo)
 }
 
+// FileRevision is a file together with the revision its content should be read from
+type FileRevision struct {
+	File     string
+	Revision string
+}
+
+// StagedOrChangedRevisions returns the revisions the contents of files should be read from
+//   - For `pre-commit` hooks it is empty, so the contents get read from the index.
+//   - For `pre-push` hooks it is the local hash of every pushed ref, deletions are skipped.
+//   - For all other hooks it is the end of every detected range.
+func StagedOrChangedRevisions(appIO io.IO) []string {
+	var revisions []string
+	add := func(revision string) {
+		if !slices.Contains(revisions, revision) {
+			revisions = append(revisions, revision)
+		}
+	}
+	switch appIO.Argument(info.ArgCommand, "") {
+	case "pre-commit":
+		return []string{""}
+	case "pre-push":
+		for _, ref := range PushRefs(appIO) {
+			if !ref.IsDeletion() {
+				add(ref.LocalHash)
+			}
+		}
+		return revisions
+	}
+	for _, r := range DetectRanges(appIO) {
+		add(r.To().Id())
+	}
+	if len(revisions) == 0 {
+		return []string{"HEAD"}
+	}
+	return revisions
+}
+
+// StagedOrChangedFileRevisions returns the staged or changed files with the revision to read them from
+//   - For `pre-commit` hooks the staged files are read from the index.
+//   - For `pre-push` hooks the files changed by every pushed ref are read from the refs local hash.
+//   - For all other hooks the files changed in every detected range are read from the end of the range.
+//
+// A file changed by multiple refs is returned once per ref.
+func StagedOrChangedFileRevisions(appIO io.IO, repo git.Repo) ([]*FileRevision, error) {
+	var fileRevisions []*FileRevision
+	add := func(files []string, revision string) {
+		for _, file := range files {
+			fileRevision := &FileRevision{File: file, Revision: revision}
+			if !slices.ContainsFunc(fileRevisions, func(f *FileRevision) bool { return *f == *fileRevision }) {
+				fileRevisions = append(fileRevisions, fileRevision)
+			}
+		}
+	}
+	switch appIO.Argument(info.ArgCommand, "") {
+	case "pre-commit":
+		files, err := repo.StagedFiles()
+		if err != nil {
+			return nil, err
+		}
+		add(files, "")
+		return fileRevisions, nil
+	case "pre-push":
+		for _, ref := range PushRefs(appIO) {
+			if ref.IsDeletion() {
+				continue
+			}
+			files, err := pushedRefFiles(ref, repo)
+			if err != nil {
+				return nil, err
+			}
+			add(files, ref.LocalHash)
+		}
+		return fileRevisions, nil
+	}
+	ranges := DetectRanges(appIO)
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("could not detect ranges")
+	}
+	for _, r := range ranges {
+		files, err := repo.ChangedFiles(r.From().Hash(), r.To().Hash())
+		if err != nil {
+			return nil, err
+		}
+		add(files, r.To().Id())
+	}
+	return fileRevisions, nil
+}
+
 // ChangedFiles will return a list of changed files
 // It uses a Detector that depending on the executed hook will use different methods to
 // detect the `from` ad `to` references.
@@ -28,3 +117,49 @@ func ChangedFiles(appIO io.IO, repo git.Repo) ([]string, error) {
 	}
 	return repo.ChangedFiles(ranges[0].From().Hash(), ranges[0].To().Hash())
 }
+
+// PushedFiles returns the files changed by all pushed refs
+// Contrary to ChangedFiles all ranges are used and new refs are supported. For new refs the files
+// of all commits not reachable from any remote ref are returned, deletions are skipped.
+func PushedFiles(appIO io.IO, repo git.Repo) ([]string, error) {
+	var files []string
+	add := func(changed []string) {
+		for _, file := range changed {
+			if !slices.Contains(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+	for _, ref := range PushRefs(appIO) {
+		if ref.IsDeletion() {
+			continue
+		}
+		changed, err := pushedRefFiles(ref, repo)
+		if err != nil {
+			return nil, err
+		}
+		add(changed)
+	}
+	return files, nil
+}
+
+// pushedRefFiles returns the files changed by a pushed ref
+// For new refs the files of all commits not reachable from any remote ref are returned.
+func pushedRefFiles(ref *PushRef, repo git.Repo) ([]string, error) {
+	if !ref.IsNew() {
+		return repo.ChangedFiles(ref.RemoteHash, ref.LocalHash)
+	}
+	var files []string
+	for _, commit := range repo.CommitsNotOnRemotes(ref.LocalHash) {
+		d, err := repo.CommitDiff(commit.Hash)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range d.Paths() {
+			if !slices.Contains(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
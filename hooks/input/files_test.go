@@ -1,6 +1,7 @@
 package input
 
 import (
+	"github.com/captainhook-go/captainhook/git/types"
 	"github.com/captainhook-go/captainhook/test"
 	"testing"
 )
@@ -45,3 +46,69 @@ func TestStagedOrChangedFilesWithBrokenInput(t *testing.T) {
 		t.Errorf("Rego should have returned 3 files")
 	}
 }
+
+func TestStagedOrChangedRevisionsWithStaged(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-commit"})
+
+	revisions := StagedOrChangedRevisions(inOut)
+
+	if len(revisions) != 1 || revisions[0] != "" {
+		t.Errorf("Revision should be empty to read from the index, got: %v", revisions)
+	}
+}
+
+func TestStagedOrChangedRevisionsWithPushedRefs(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876\n" +
+		"refs/heads/foo 67890 refs/heads/foo 0000000000000000000000000000000000000000\n" +
+		"(delete) 0000000000000000000000000000000000000000 refs/heads/bar 54321\n"})
+
+	revisions := StagedOrChangedRevisions(inOut)
+
+	if len(revisions) != 2 || revisions[0] != "12345" || revisions[1] != "67890" {
+		t.Errorf("Revisions should be the local hashes of all pushed refs, got: %v", revisions)
+	}
+}
+
+func TestStagedOrChangedFileRevisionsWithPushedRefs(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876\n" +
+		"refs/heads/foo 67890 refs/heads/foo 0000000000000000000000000000000000000000\n"})
+	repo := test.CreateFakeRepo()
+	repo.SetFiles([]string{"foo"})
+	repo.SetLog([]*types.Commit{{Hash: "abc1234"}})
+	repo.SetDiff(&types.Diff{Files: []*types.FileDiff{{NewPath: "foo"}, {NewPath: "bar"}}})
+
+	fileRevisions, err := StagedOrChangedFileRevisions(inOut, repo)
+
+	expected := []FileRevision{{"foo", "12345"}, {"foo", "67890"}, {"bar", "67890"}}
+	if err != nil || len(fileRevisions) != len(expected) {
+		t.Fatalf("Files of all pushed refs should be returned, got: %d", len(fileRevisions))
+	}
+	for i, fileRevision := range fileRevisions {
+		if *fileRevision != expected[i] {
+			t.Errorf("File should be read from the ref changing it, expected %v got: %v", expected[i], *fileRevision)
+		}
+	}
+}
+
+func TestPushedFiles(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876\n" +
+		"refs/heads/foo 12345 refs/heads/foo 0000000000000000000000000000000000000000\n" +
+		"refs/tags/v1.0.0 12345 refs/tags/v1.0.0 0000000000000000000000000000000000000000\n"})
+	repo := test.CreateFakeRepo()
+	repo.SetFiles([]string{"foo", "bar"})
+	repo.SetLog([]*types.Commit{{Hash: "abc1234"}})
+	repo.SetDiff(&types.Diff{Files: []*types.FileDiff{{NewPath: "baz"}}})
+
+	files, err := PushedFiles(inOut, repo)
+
+	if err != nil || len(files) != 3 {
+		t.Errorf("Files of all pushed refs should be returned, got: %v", files)
+	}
+}
@@ -0,0 +1,94 @@
+package input
+
+import (
+	"github.com/captainhook-go/captainhook/git"
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// PushRef represents a single ref update from the pre-push hook input
+//
+//	<local ref> SP <local sha1> SP <remote ref> SP <remote sha1> LF
+type PushRef struct {
+	LocalRef   string
+	LocalHash  string
+	RemoteRef  string
+	RemoteHash string
+}
+
+// IsDeletion tells you if the remote ref gets deleted
+func (r *PushRef) IsDeletion() bool {
+	return git.IsZeroHash(r.LocalHash)
+}
+
+// IsNew tells you if the remote ref does not exist yet
+func (r *PushRef) IsNew() bool {
+	return git.IsZeroHash(r.RemoteHash)
+}
+
+// IsBranch tells you if the remote ref is a branch
+func (r *PushRef) IsBranch() bool {
+	return strings.HasPrefix(r.RemoteRef, "refs/heads/")
+}
+
+// Branch returns the full name of the remote branch e.g. 'feature/foo'
+func (r *PushRef) Branch() string {
+	return strings.TrimPrefix(r.RemoteRef, "refs/heads/")
+}
+
+// IsTag tells you if the remote ref is a tag
+func (r *PushRef) IsTag() bool {
+	return strings.HasPrefix(r.RemoteRef, "refs/tags/")
+}
+
+// Tag returns the name of the remote tag e.g. 'v1.0.0'
+func (r *PushRef) Tag() string {
+	return strings.TrimPrefix(r.RemoteRef, "refs/tags/")
+}
+
+// PushRefs returns all ref updates from the pre-push hook input
+// Contrary to DetectRanges new refs and deletions are included.
+func PushRefs(appIO io.IO) []*PushRef {
+	var refs []*PushRef
+	for _, line := range io.SplitLines(appIO.Option("input", "")) {
+		p := strings.Fields(line)
+		if len(p) < 4 {
+			continue
+		}
+		refs = append(refs, &PushRef{
+			LocalRef:   p[LocalRef],
+			LocalHash:  p[LocalHash],
+			RemoteRef:  p[RemoteRef],
+			RemoteHash: p[RemoteHash],
+		})
+	}
+	return refs
+}
+
+// PushedCommits returns all commits that get pushed to the remote
+// For new refs all commits not reachable from any remote ref are returned, deletions are skipped.
+// Commits pushed to multiple refs are only returned once.
+func PushedCommits(appIO io.IO, repo git.Repo) []*types.Commit {
+	var commits []*types.Commit
+	seen := map[string]bool{}
+	for _, ref := range PushRefs(appIO) {
+		if ref.IsDeletion() {
+			continue
+		}
+		var refCommits []*types.Commit
+		if ref.IsNew() {
+			refCommits = repo.CommitsNotOnRemotes(ref.LocalHash)
+		} else {
+			refCommits = repo.CommitsBetween(ref.RemoteHash, ref.LocalHash)
+		}
+		for _, commit := range refCommits {
+			if seen[commit.Hash] {
+				continue
+			}
+			seen[commit.Hash] = true
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
@@ -0,0 +1,44 @@
+package input
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/test"
+	"testing"
+)
+
+func TestPushRefs(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetOptions(map[string]string{"input": "refs/heads/feature/foo 12345 refs/heads/feature/foo 0000000\n" +
+		"(delete) 0000000 refs/heads/old 09876\n" +
+		"refs/tags/v1.0.0 12345 refs/tags/v1.0.0 0000000\n"})
+
+	refs := PushRefs(inOut)
+
+	if len(refs) != 3 {
+		t.Fatalf("Should have found 3 refs, got: %d", len(refs))
+	}
+	if !refs[0].IsNew() || refs[0].IsDeletion() || refs[0].Branch() != "feature/foo" {
+		t.Errorf("First ref should be the new branch 'feature/foo'")
+	}
+	if !refs[1].IsDeletion() || refs[1].Branch() != "old" {
+		t.Errorf("Second ref should be the deletion of 'old'")
+	}
+	if refs[2].IsBranch() || !refs[2].IsTag() || refs[2].Tag() != "v1.0.0" {
+		t.Errorf("Third ref should be the tag 'v1.0.0'")
+	}
+}
+
+func TestPushedCommits(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetOptions(map[string]string{"input": "refs/heads/foo 12345 refs/heads/foo 0000000\n" +
+		"refs/heads/bar 12345 refs/heads/bar 09876\n" +
+		"(delete) 0000000 refs/heads/old 09876\n"})
+	repo := test.CreateFakeRepo()
+	repo.SetLog([]*types.Commit{{Hash: "12345"}, {Hash: "12344"}})
+
+	commits := PushedCommits(inOut, repo)
+
+	if len(commits) != 2 {
+		t.Errorf("Commits pushed to multiple refs should be returned once, got: %d", len(commits))
+	}
+}
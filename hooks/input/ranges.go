@@ -29,8 +29,8 @@ var (
 					continue
 				}
 
-				from := types.NewRef(p[RemoteHash], p[RemoteHash], git.ExtractBranchFromRefPath(p[RemoteRef]))
-				to := types.NewRef(p[LocalHash], p[LocalHash], git.ExtractBranchFromRefPath(p[LocalRef]))
+				from := pushedRef(p[RemoteHash], p[RemoteRef])
+				to := pushedRef(p[LocalHash], p[LocalRef])
 				ranges = append(ranges, types.NewRange(from, to))
 			}
 			return ranges
@@ -63,6 +63,14 @@ var (
 	}
 )
 
+// pushedRef creates a Ref for a pre-push ref path, tags are marked as such instead of being named like branches
+func pushedRef(hash, refPath string) *types.Ref {
+	if strings.HasPrefix(refPath, "refs/tags/") {
+		return types.NewTagRef(hash, hash, strings.TrimPrefix(refPath, "refs/tags/"))
+	}
+	return types.NewRef(hash, hash, git.ExtractBranchFromRefPath(refPath))
+}
+
 func DetectRanges(appIO io.IO) []*types.Range {
 	command := appIO.Argument(info.ArgCommand, "fallback")
 
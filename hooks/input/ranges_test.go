@@ -0,0 +1,24 @@
+package input
+
+import (
+	"github.com/captainhook-go/captainhook/test"
+	"testing"
+)
+
+func TestDetectRangesKeepsTags(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876\n" +
+		"refs/tags/v1.0.0 12345 refs/tags/v1.0.0 09876\n"})
+
+	ranges := DetectRanges(inOut)
+
+	// tags can point to commits that are not pushed yet, so they need to be checked as well
+	if len(ranges) != 2 || ranges[0].To().Branch() != "main" || ranges[0].To().IsTag() {
+		t.Fatalf("The branch and the tag should be detected, got %d ranges", len(ranges))
+	}
+	tag := ranges[1].To()
+	if !tag.IsTag() || tag.Tag() != "v1.0.0" || tag.Branch() != "" || !ranges[1].From().IsTag() {
+		t.Errorf("Tags should be marked as tags and not have a branch, got tag '%s' branch '%s'", tag.Tag(), tag.Branch())
+	}
+}
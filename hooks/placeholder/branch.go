@@ -0,0 +1,27 @@
+package placeholder
+
+import (
+	"github.com/captainhook-go/captainhook/hooks/app"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"strings"
+)
+
+// Branch replaces {$BRANCH} with the current branch
+// In 'pre-push' hooks the names of all pushed branches are used.
+type Branch struct {
+	context *app.Context
+}
+
+func (r *Branch) Replacement(options map[string]string) string {
+	if r.context.IO().Argument(info.ArgCommand, "") != info.PrePush {
+		return r.context.Repository().BranchName()
+	}
+	var branches []string
+	for _, ref := range input.PushRefs(r.context.IO()) {
+		if ref.IsBranch() {
+			branches = append(branches, ref.Branch())
+		}
+	}
+	return strings.Join(branches, ", ")
+}
@@ -0,0 +1,43 @@
+package placeholder
+
+import (
+	"github.com/captainhook-go/captainhook/git/types"
+	"github.com/captainhook-go/captainhook/test"
+	"testing"
+)
+
+func TestBranch(t *testing.T) {
+	repo := test.CreateFakeRepo().SetBranch("feature/foo")
+	ctx := test.CreateFakeHookContext(test.CreateFakeIO(), test.CreateFakeConfig(), repo)
+
+	placeholder := &Branch{context: ctx}
+	if result := placeholder.Replacement(map[string]string{}); result != "feature/foo" {
+		t.Errorf("Replacement didn't work, got: %s, want: %s.", result, "feature/foo")
+	}
+}
+
+func TestBranchAndCommitCountOnPush(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/main 12345 refs/heads/main 09876"})
+	repo := test.CreateFakeRepo().SetLog([]*types.Commit{{Hash: "abc1234"}, {Hash: "def5678"}})
+	ctx := test.CreateFakeHookContext(inOut, test.CreateFakeConfig(), repo)
+
+	result := ReplacePlaceholders(ctx, "pushing {$COMMIT_COUNT} commits to {$BRANCH}")
+	if result != "pushing 2 commits to main" {
+		t.Errorf("Replacement didn't work, got: %s", result)
+	}
+}
+
+func TestCommitCountOnNewBranch(t *testing.T) {
+	inOut := test.CreateFakeIO()
+	inOut.SetArguments(map[string]string{"command": "pre-push"})
+	inOut.SetOptions(map[string]string{"input": "refs/heads/foo 12345 refs/heads/foo 0000000000000000000000000000000000000000"})
+	repo := test.CreateFakeRepo().SetLog([]*types.Commit{{Hash: "abc1234"}, {Hash: "def5678"}})
+	ctx := test.CreateFakeHookContext(inOut, test.CreateFakeConfig(), repo)
+
+	placeholder := &CommitCount{context: ctx}
+	if result := placeholder.Replacement(map[string]string{}); result != "2" {
+		t.Errorf("Commits of new branches should be counted, got: %s", result)
+	}
+}
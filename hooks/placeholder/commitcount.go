@@ -0,0 +1,26 @@
+package placeholder
+
+import (
+	"github.com/captainhook-go/captainhook/hooks/app"
+	"github.com/captainhook-go/captainhook/hooks/input"
+	"github.com/captainhook-go/captainhook/info"
+	"strconv"
+)
+
+// CommitCount replaces {$COMMIT_COUNT} with the number of commits that are pushed or rewritten
+// For new branches all pushed commits not yet on any remote are counted.
+type CommitCount struct {
+	context *app.Context
+}
+
+func (r *CommitCount) Replacement(options map[string]string) string {
+	appIO := r.context.IO()
+	if appIO.Argument(info.ArgCommand, "") == info.PrePush {
+		return strconv.Itoa(len(input.PushedCommits(appIO, r.context.Repository())))
+	}
+	count := 0
+	for _, aRange := range input.DetectRanges(appIO) {
+		count += len(r.context.Repository().CommitsBetween(aRange.From().Id(), aRange.To().Id()))
+	}
+	return strconv.Itoa(count)
+}
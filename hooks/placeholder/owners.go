@@ -0,0 +1,26 @@
+package placeholder
+
+import (
+	"github.com/captainhook-go/captainhook/hooks/app"
+	"github.com/captainhook-go/captainhook/hooks/codeowners"
+	"github.com/captainhook-go/captainhook/io"
+	"strings"
+)
+
+// Owners replaces {$OWNERS} with the CODEOWNERS owners of all staged or changed files
+type Owners struct {
+	context *app.Context
+	files   []string
+}
+
+func (r *Owners) Replacement(options map[string]string) string {
+	owners, err := codeowners.Load(r.context.Repository())
+	if err != nil {
+		r.context.IO().Write(err.Error(), true, io.VERBOSE)
+		return ""
+	}
+	return strings.Join(
+		owners.AllOwners(r.files),
+		io.MappedStringOrDefault(options, "separated-by", " "),
+	)
+}
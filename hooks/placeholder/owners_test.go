@@ -0,0 +1,23 @@
+package placeholder
+
+import (
+	"github.com/captainhook-go/captainhook/test"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOwners(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, ".github"), 0755)
+	_ = os.WriteFile(filepath.Join(dir, ".github", "CODEOWNERS"), []byte("* @acme/core\n*.js @acme/frontend\n"), 0644)
+
+	repo := test.CreateFakeRepo().SetPath(dir)
+	ctx := test.CreateFakeHookContext(test.CreateFakeIO(), test.CreateFakeConfig(), repo)
+
+	placeholder := &Owners{context: ctx, files: []string{"main.go", "app.js"}}
+	result := placeholder.Replacement(map[string]string{"separated-by": ","})
+	if result != "@acme/core,@acme/frontend" {
+		t.Errorf("Replacement didn't work, got: %s", result)
+	}
+}
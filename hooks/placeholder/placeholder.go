@@ -15,6 +15,12 @@ var (
 		"ARG": func(aContext *app.Context) Replacer {
 			return &Args{context: aContext}
 		},
+		"BRANCH": func(aContext *app.Context) Replacer {
+			return &Branch{context: aContext}
+		},
+		"COMMIT_COUNT": func(aContext *app.Context) Replacer {
+			return &CommitCount{context: aContext}
+		},
 		"CONFIG": func(aContext *app.Context) Replacer {
 			return &ConfigValue{context: aContext}
 		},
@@ -24,6 +30,13 @@ var (
 		"ENV": func(aContext *app.Context) Replacer {
 			return &EnvVar{context: aContext}
 		},
+		"OWNERS": func(aContext *app.Context) Replacer {
+			files := collectAllChangedFiles(aContext)
+			if aContext.IO().Argument(info.ArgCommand, "") == info.PreCommit {
+				files, _ = aContext.Repository().StagedFiles()
+			}
+			return &Owners{context: aContext, files: files}
+		},
 		"STAGED_FILES": func(aContext *app.Context) Replacer {
 			files, _ := aContext.Repository().StagedFiles()
 			return &FileList{name: "STAGED_FILES", context: aContext, files: files}
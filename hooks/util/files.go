@@ -2,6 +2,7 @@ package util
 
 import (
 	"path"
+	"regexp"
 	"slices"
 	"strings"
 )
@@ -30,6 +31,61 @@ func FilterByDirectory(files []string, inDir string) []string {
 	return filtered
 }
 
+// FilterByPattern removes all files from a slice that do not match a given glob pattern
+// See MatchesPattern for the supported pattern syntax.
+func FilterByPattern(files []string, pattern string) []string {
+	var filtered []string
+	for _, file := range files {
+		if MatchesPattern(file, pattern) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+// MatchesPattern checks if a file path matches a glob pattern
+//   - '*' matches everything except '/'
+//   - '**' matches everything including '/'
+//   - '?' matches a single character except '/'
+//   - patterns without a '/' except a trailing one match in any directory
+//   - patterns ending with '/' match everything inside a directory
+func MatchesPattern(file string, pattern string) bool {
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		pattern = "**/" + pattern
+	}
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	matched, _ := regexp.MatchString(globToRegex(strings.TrimPrefix(pattern, "/")), file)
+	return matched
+}
+
+// globToRegex converts a glob pattern to an anchored regular expression
+func globToRegex(pattern string) string {
+	var regex strings.Builder
+	regex.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				regex.WriteString("(.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				regex.WriteString(".*")
+				i++
+			} else {
+				regex.WriteString("[^/]*")
+			}
+		case '?':
+			regex.WriteString("[^/]")
+		default:
+			regex.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	regex.WriteString("$")
+	return regex.String()
+}
+
 // ContainsAllStrings checks if a haystack contains all needles
 func ContainsAllStrings(haystack []string, needles []string) bool {
 	for _, file := range needles {
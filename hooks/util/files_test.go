@@ -62,3 +62,37 @@ func TestDoesNotContainAnyStrings(t *testing.T) {
 		t.Errorf("Files should not contain either 'fiz' or 'faz'")
 	}
 }
+
+func TestMatchesPattern(t *testing.T) {
+	tests := []struct {
+		file    string
+		pattern string
+		matches bool
+	}{
+		{"go.mod", "go.mod", true},
+		{"tools/go.mod", "go.mod", true},
+		{"tools/go.mod", "/go.mod", false},
+		{"db/migrations/001.sql", "db/migrations/*.sql", true},
+		{"db/migrations/old/001.sql", "db/migrations/*.sql", false},
+		{"db/migrations/old/001.sql", "db/**/*.sql", true},
+		{"docs/api/index.md", "docs/", true},
+		{"src/docs/index.md", "docs/", true},
+		{"src/docs/index.md", "/docs/", false},
+		{"main.go", "*.go", true},
+		{"main.go.txt", "*.go", false},
+	}
+	for _, test := range tests {
+		if MatchesPattern(test.file, test.pattern) != test.matches {
+			t.Errorf("File '%s' should match '%s': %t", test.file, test.pattern, test.matches)
+		}
+	}
+}
+
+func TestFilterByPattern(t *testing.T) {
+	files := []string{"go.mod", "go.sum", "main.go"}
+
+	filtered := FilterByPattern(files, "go.*")
+	if len(filtered) != 2 {
+		t.Errorf("Filter should remove all but two")
+	}
+}
@@ -45,7 +45,7 @@ func (c *CollectorIO) Input() Input {
 }
 
 func (c *CollectorIO) IsInteractive() bool {
-	return false
+	return c.input.IsInteractive()
 }
 
 func (c *CollectorIO) IsDebug() bool {
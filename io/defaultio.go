@@ -43,7 +43,7 @@ func (d *DefaultIO) Input() Input {
 }
 
 func (d *DefaultIO) IsInteractive() bool {
-	return false
+	return d.input.IsInteractive()
 }
 
 func (d *DefaultIO) IsQuiet() bool {
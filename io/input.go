@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sync"
 )
 
 type Input interface {
@@ -13,16 +14,24 @@ type Input interface {
 	Argument(name, defaultValue string) string
 	Arguments() map[string]string
 	Ask(message, defaultValue string) string
+	IsInteractive() bool
+	DisableInteraction()
 }
 
 type StdIn struct {
+	// mutex guards the reader and the stdin data, actions asking questions may run in parallel
+	mutex       sync.Mutex
+	reader      *bufio.Reader
 	stdInLoaded bool
 	stdInData   []string
 	options     map[string]string
 	arguments   map[string]string
+	noInteract  bool
 }
 
 func (s *StdIn) Data() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if !s.stdInLoaded {
 		s.stdInData = s.readStdIn()
 		s.stdInLoaded = true
@@ -66,10 +75,15 @@ func (s *StdIn) Ask(message, defaultValue string) string {
 }
 
 func (s *StdIn) askForUserInput(message string) (string, error) {
-	reader := bufio.NewReader(os.Stdin)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// keep the reader so buffered input is not lost between questions
+	if s.reader == nil {
+		s.reader = bufio.NewReader(os.Stdin)
+	}
 	fmt.Print(Colorize(message))
 
-	input, _, err := reader.ReadLine()
+	input, _, err := s.reader.ReadLine()
 	return string(input), err
 }
 
@@ -88,6 +102,17 @@ func (s *StdIn) readStdIn() []string {
 	return lines
 }
 
+// IsInteractive tells you if questions can be asked
+// This is only the case if stdin is a terminal and interaction was not disabled.
+func (s *StdIn) IsInteractive() bool {
+	return !s.noInteract && !s.isPiped()
+}
+
+// DisableInteraction makes sure no questions are asked e.g. if '--no-interaction' is used
+func (s *StdIn) DisableInteraction() {
+	s.noInteract = true
+}
+
 func (s *StdIn) isPiped() bool {
 	stat, err := os.Stdin.Stat()
 	if err != nil {
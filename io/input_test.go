@@ -0,0 +1,12 @@
+package io
+
+import "testing"
+
+func TestDisableInteraction(t *testing.T) {
+	in := NewStdIn(map[string]string{}, map[string]string{})
+	in.DisableInteraction()
+
+	if in.IsInteractive() {
+		t.Errorf("Input should not be interactive after interaction was disabled")
+	}
+}
@@ -7,12 +7,23 @@ type IOMock struct {
 	opts  map[string]string
 	args  map[string]string
 	Out   []string
+	// answers are returned by Ask in order, afterward the default value is returned
+	answers        []string
+	notInteractive bool
 }
 
 func (inOut *IOMock) SetStdIn(input []string) {
 	inOut.stdIn = input
 }
 
+func (inOut *IOMock) SetAnswers(answers []string) {
+	inOut.answers = answers
+}
+
+func (inOut *IOMock) SetInteractive(interactive bool) {
+	inOut.notInteractive = !interactive
+}
+
 func (inOut *IOMock) SetArguments(args map[string]string) {
 	inOut.args = args
 }
@@ -58,7 +69,7 @@ func (inOut *IOMock) Input() io.Input {
 }
 
 func (inOut *IOMock) IsInteractive() bool {
-	return true
+	return !inOut.notInteractive
 }
 
 func (inOut *IOMock) IsDebug() bool {
@@ -78,5 +89,11 @@ func (inOut *IOMock) Write(message string, newline bool, verbosity int) {
 }
 
 func (inOut *IOMock) Ask(message string, defaultValue string) string {
-	return ""
+	inOut.Out = append(inOut.Out, message)
+	if len(inOut.answers) == 0 {
+		return defaultValue
+	}
+	answer := inOut.answers[0]
+	inOut.answers = inOut.answers[1:]
+	return answer
 }
@@ -10,7 +10,26 @@ type RepoMock struct {
 	path             string
 	branch           string
 	fileList         []string
+	fileContents     map[string]string
+	diff             *types.Diff
 	log              []*types.Commit
+	notAncestor      bool
+	ahead            int
+	behind           int
+	merges           []string
+	upstream         string
+	config           map[string]string
+	objectTypes      map[string]string
+	tags             []string
+	commitMessage    string
+	Added            []string
+	// Prepared is the last message passed to PrepareCommitMessage
+	Prepared *types.CommitMessage
+}
+
+func (r *RepoMock) SetPath(path string) *RepoMock {
+	r.path = path
+	return r
 }
 
 func (r *RepoMock) SetBranch(name string) *RepoMock {
@@ -18,11 +37,67 @@ func (r *RepoMock) SetBranch(name string) *RepoMock {
 	return r
 }
 
+func (r *RepoMock) SetCommitMessage(msg string) *RepoMock {
+	r.commitMessage = msg
+	return r
+}
+
 func (r *RepoMock) SetFiles(files []string) *RepoMock {
 	r.fileList = files
 	return r
 }
 
+func (r *RepoMock) SetFileContents(contents map[string]string) *RepoMock {
+	r.fileContents = contents
+	return r
+}
+
+func (r *RepoMock) SetDiff(diff *types.Diff) *RepoMock {
+	r.diff = diff
+	return r
+}
+
+func (r *RepoMock) SetLog(log []*types.Commit) *RepoMock {
+	r.log = log
+	return r
+}
+
+func (r *RepoMock) SetIsAncestor(isAncestor bool) *RepoMock {
+	r.notAncestor = !isAncestor
+	return r
+}
+
+func (r *RepoMock) SetAheadBehind(ahead, behind int) *RepoMock {
+	r.ahead = ahead
+	r.behind = behind
+	return r
+}
+
+func (r *RepoMock) SetMergeCommits(merges []string) *RepoMock {
+	r.merges = merges
+	return r
+}
+
+func (r *RepoMock) SetUpstream(upstream string) *RepoMock {
+	r.upstream = upstream
+	return r
+}
+
+func (r *RepoMock) SetConfig(config map[string]string) *RepoMock {
+	r.config = config
+	return r
+}
+
+func (r *RepoMock) SetObjectTypes(objectTypes map[string]string) *RepoMock {
+	r.objectTypes = objectTypes
+	return r
+}
+
+func (r *RepoMock) SetTags(tags []string) *RepoMock {
+	r.tags = tags
+	return r
+}
+
 func (r *RepoMock) SetFilesError(triggerError bool) *RepoMock {
 	r.triggerFileError = triggerError
 	return r
@@ -56,15 +131,19 @@ func (r *RepoMock) HooksDir() string {
 }
 
 func (r *RepoMock) CommitMessage(path string) (*types.CommitMessage, error) {
-	return types.NewCommitMessage("", "#"), nil
+	return types.NewCommitMessage(r.commitMessage, "#"), nil
 }
 
 func (r *RepoMock) PrepareCommitMessage(path string, msg *types.CommitMessage) error {
+	r.Prepared = msg
 	return nil
 }
 
 func (r *RepoMock) ConfigValue(value string, defaultValue string) string {
-	return ""
+	if configured, ok := r.config[value]; ok {
+		return configured
+	}
+	return defaultValue
 }
 
 func (r *RepoMock) IsMerging() bool {
@@ -75,6 +154,15 @@ func (r *RepoMock) StagedFiles() ([]string, error) {
 	return r.files()
 }
 
+func (r *RepoMock) AddFiles(files []string) error {
+	r.Added = append(r.Added, files...)
+	return nil
+}
+
+func (r *RepoMock) TrackedFiles() ([]string, error) {
+	return r.files()
+}
+
 func (r *RepoMock) ChangedFiles(from, to string) ([]string, error) {
 	return r.files()
 }
@@ -84,5 +172,82 @@ func (r *RepoMock) BranchName() string {
 }
 
 func (r *RepoMock) CommitsBetween(from string, to string) []*types.Commit {
-	return []*types.Commit{}
+	if r.log == nil {
+		return []*types.Commit{}
+	}
+	return r.log
+}
+
+func (r *RepoMock) CommitsNotOnRemotes(to string) []*types.Commit {
+	return r.CommitsBetween("", to)
+}
+
+func (r *RepoMock) IsAncestor(ancestor, descendant string) bool {
+	return !r.notAncestor
+}
+
+func (r *RepoMock) MergeBase(a, b string) (string, error) {
+	return b, nil
+}
+
+func (r *RepoMock) AheadBehind(local, base string) (int, int, error) {
+	return r.ahead, r.behind, nil
+}
+
+func (r *RepoMock) MergeCommitsBetween(from, to string) ([]string, error) {
+	return r.merges, nil
+}
+
+func (r *RepoMock) Upstream(branch string) string {
+	return r.upstream
+}
+
+func (r *RepoMock) ObjectType(object string) (string, error) {
+	objectType, ok := r.objectTypes[object]
+	if !ok {
+		return "commit", nil
+	}
+	return objectType, nil
+}
+
+func (r *RepoMock) Tags() ([]string, error) {
+	return r.tags, nil
+}
+
+func (r *RepoMock) StagedDiff() (*types.Diff, error) {
+	return r.currentDiff()
+}
+
+func (r *RepoMock) DiffBetween(from, to string) (*types.Diff, error) {
+	return r.currentDiff()
+}
+
+func (r *RepoMock) CommitDiff(hash string) (*types.Diff, error) {
+	return r.currentDiff()
+}
+
+func (r *RepoMock) currentDiff() (*types.Diff, error) {
+	if r.triggerFileError {
+		return nil, errors.New("diff error")
+	}
+	if r.diff == nil {
+		return &types.Diff{}, nil
+	}
+	return r.diff, nil
+}
+
+func (r *RepoMock) FileContent(path, revision string) ([]byte, error) {
+	content, ok := r.fileContents[path]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return []byte(content), nil
+}
+
+func (r *RepoMock) FileSize(path, revision string) (int64, error) {
+	content, err := r.FileContent(path, revision)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(content)), nil
 }